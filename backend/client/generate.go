@@ -0,0 +1,10 @@
+// Package client is a Go client for the Predictum HTTP API, generated from
+// internal/openapi/openapi.json. Regenerate it with `go generate ./client`
+// after changing the spec.
+//
+//	c, err := client.NewClientWithResponses("http://localhost:3001/api")
+//	resp, err := c.ListMarketsWithResponse(ctx, &client.ListMarketsParams{})
+//	for _, m := range resp.JSON200.Markets { ... }
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.4.1 -config oapi-codegen.yaml ../internal/openapi/openapi.json
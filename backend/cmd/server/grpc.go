@@ -0,0 +1,37 @@
+package main
+
+import (
+	"log"
+	"net"
+
+	"github.com/linera-prediction-market/backend/internal/events"
+	"github.com/linera-prediction-market/backend/internal/grpcapi"
+	"github.com/linera-prediction-market/backend/internal/market"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// startGRPC serves the gRPC API on addr in the background. The AdminService
+// requires adminToken when it is set, and reflection is only offered when
+// reflect is true.
+func startGRPC(addr string, markets *market.Service, bus *events.Bus, adminToken string, reflect bool) *grpc.Server {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("❌ Failed to listen for gRPC on %s: %v", addr, err)
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpcapi.AdminAuth(adminToken)))
+	grpcapi.Register(server, markets, bus)
+	if reflect {
+		reflection.Register(server)
+	}
+
+	go func() {
+		log.Printf("📡 gRPC API running on %s", addr)
+		if err := server.Serve(listener); err != nil {
+			log.Printf("❌ gRPC server stopped: %v", err)
+		}
+	}()
+
+	return server
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/linera-prediction-market/backend/internal/config"
+	"github.com/linera-prediction-market/backend/internal/db"
+)
+
+const migrateUsage = `Usage: server migrate [flags] <command>
+
+Commands:
+  up [n]     apply all (or the next n) pending migrations
+  down [n]   revert the last (or last n) applied migrations
+  status     list migrations and whether they have been applied`
+
+// runMigrate implements the "migrate" subcommand for the configured SQL backend
+func runMigrate(cfg config.Storage, args []string) {
+	if len(args) == 0 {
+		fmt.Println(migrateUsage)
+		os.Exit(2)
+	}
+
+	steps := 0
+	if len(args) > 1 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			log.Fatalf("❌ Invalid step count: %s", args[1])
+		}
+		steps = n
+	}
+
+	database, err := connectDatabase(cfg)
+	if err != nil {
+		log.Fatalf("❌ Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	switch args[0] {
+	case "up":
+		err = database.MigrateUp(steps)
+	case "down":
+		if steps == 0 {
+			steps = 1
+		}
+		err = database.MigrateDown(steps)
+	case "status":
+		var statuses []db.MigrationStatus
+		statuses, err = database.MigrationStatuses()
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		fmt.Println(migrateUsage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("❌ Migration failed: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/linera-prediction-market/backend/internal/config"
+	"github.com/linera-prediction-market/backend/internal/fixtures"
+)
+
+// seedUsage lists the fixture sets the seed command can load
+func seedUsage() string {
+	var b strings.Builder
+	b.WriteString("Usage: server seed [flags] <fixture>\n\nFixtures:")
+	for _, name := range fixtures.Names() {
+		fmt.Fprintf(&b, "\n  %-10s %s", name, fixtures.Sets[name].Description)
+	}
+	return b.String()
+}
+
+// runSeed implements the "seed" subcommand, which loads a fixture set into an
+// empty postgres or sqlite store
+func runSeed(cfg *config.Config, args []string) {
+	if len(args) != 1 {
+		fmt.Println(seedUsage())
+		os.Exit(2)
+	}
+	if _, ok := fixtures.Sets[args[0]]; !ok {
+		fmt.Println(seedUsage())
+		os.Exit(2)
+	}
+	if cfg.Mode == config.ModeProduction {
+		log.Fatalf("❌ Refusing to seed demo data in production mode")
+	}
+	if cfg.Storage.Backend == "memory" {
+		log.Fatalf("❌ In-memory storage does not persist; start the server with -seed %s instead", args[0])
+	}
+
+	store, closeStorage, err := openStorage(cfg.Storage)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize storage: %v", err)
+	}
+	defer closeStorage()
+
+	markets, positions, err := fixtures.Seed(context.Background(), store, args[0])
+	if errors.Is(err, fixtures.ErrNotEmpty) {
+		log.Printf("📊 Markets already exist, nothing seeded")
+		return
+	}
+	if err != nil {
+		// log.Fatalf skips deferred calls, so close the store first
+		closeStorage()
+		log.Fatalf("❌ Failed to seed %s: %v", args[0], err)
+	}
+	log.Printf("🌱 Seeded %d markets and %d positions from %s", markets, positions, args[0])
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+	"log"
+)
+
+// drain calls stop and waits for it to return, giving up once ctx is done
+func drain(ctx context.Context, what string, stop func()) {
+	done := make(chan struct{})
+	go func() {
+		stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Printf("⚠️  Gave up waiting for %s: %v", what, ctx.Err())
+	}
+}
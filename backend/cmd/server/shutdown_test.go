@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDrainWaitsForStop(t *testing.T) {
+	finished := false
+	stop := func() {
+		time.Sleep(50 * time.Millisecond)
+		finished = true
+	}
+
+	drain(context.Background(), "job", stop)
+	if !finished {
+		t.Fatal("drain returned before the outstanding job finished")
+	}
+}
+
+func TestDrainGivesUpAtDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	drain(ctx, "stuck job", func() { <-release })
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("drain took %v, want it to give up at the deadline", elapsed)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("drain returned before the deadline")
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/linera-prediction-market/backend/internal/config"
+	"github.com/linera-prediction-market/backend/internal/db"
+	"github.com/linera-prediction-market/backend/internal/events"
+	"github.com/linera-prediction-market/backend/internal/fixtures"
+	"github.com/linera-prediction-market/backend/internal/handlers"
+	"github.com/linera-prediction-market/backend/internal/history"
+	"github.com/linera-prediction-market/backend/internal/leaderboard"
+	"github.com/linera-prediction-market/backend/internal/market"
+	"github.com/linera-prediction-market/backend/internal/storage"
+)
+
+// appStorage is everything the server needs from a storage backend
+type appStorage interface {
+	handlers.StorageInterface
+	market.Store
+	events.Store
+	history.RetentionStore
+	leaderboard.StorageInterface
+	fixtures.Store
+}
+
+// connectDatabase opens the SQL database behind a "postgres" or "sqlite" backend
+func connectDatabase(cfg config.Storage) (*db.DB, error) {
+	switch cfg.Backend {
+	case "postgres":
+		return db.Connect(cfg.DatabaseURL)
+	case "sqlite":
+		return db.ConnectSQLite(cfg.SQLitePath)
+	default:
+		return nil, fmt.Errorf("storage backend %q has no database", cfg.Backend)
+	}
+}
+
+// openStorage creates the selected storage backend ("postgres", "sqlite" or
+// "memory") and returns it with a function that releases its resources
+func openStorage(cfg config.Storage) (appStorage, func() error, error) {
+	switch cfg.Backend {
+	case "postgres", "sqlite":
+		database, err := connectDatabase(cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+
+		// Bring the schema up to date before touching any tables
+		if err := database.Migrate(); err != nil {
+			database.Close()
+			return nil, nil, fmt.Errorf("failed to apply database migrations: %w", err)
+		}
+
+		if database.Driver == db.DriverSQLite {
+			return storage.NewSQLiteStorage(database), database.Close, nil
+		}
+		return storage.NewPostgresStorage(database), database.Close, nil
+	case "memory":
+		log.Println("🧠 Using in-memory storage (data is lost on restart)")
+		return storage.New(), func() error { return nil }, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage backend %q (use postgres, sqlite or memory)", cfg.Backend)
+	}
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// setting is a value that can be overridden by an environment variable and a flag
+type setting struct {
+	flag   string
+	env    string
+	usage  string
+	isBool bool
+	set    func(c *Config, raw string) error
+}
+
+// settings lists every environment variable and flag, in the order they are applied
+var settings = []setting{
+	stringSetting("mode", "MODE", "demo or production", func(c *Config) *string { return &c.Mode }),
+
+	stringSetting("addr", "HTTP_ADDR", "HTTP listen address", func(c *Config) *string { return &c.Server.Addr }),
+	stringSetting("grpc-addr", "GRPC_ADDR", "gRPC listen address", func(c *Config) *string { return &c.Server.GRPCAddr }),
+	listSetting("allowed-origins", "CORS_ORIGINS", "comma-separated origins allowed by CORS and WebSockets", func(c *Config) *[]string { return &c.Server.AllowedOrigins }),
+	durationSetting("shutdown-timeout", "SHUTDOWN_TIMEOUT", "how long shutdown waits for requests and background work", func(c *Config) *time.Duration { return &c.Server.ShutdownTimeout }),
+	stringSetting("admin-token", "ADMIN_TOKEN", "bearer token required by admin routes and the gRPC AdminService", func(c *Config) *string { return &c.Server.AdminToken }),
+
+	stringSetting("storage", "STORAGE", "storage backend: postgres, sqlite or memory", func(c *Config) *string { return &c.Storage.Backend }),
+	stringSetting("database-url", "DATABASE_URL", "PostgreSQL connection URL", func(c *Config) *string { return &c.Storage.DatabaseURL }),
+	stringSetting("sqlite-path", "SQLITE_PATH", "SQLite database file", func(c *Config) *string { return &c.Storage.SQLitePath }),
+	stringSetting("seed", "SEED", "fixture set to load into an empty store at startup", func(c *Config) *string { return &c.Storage.Seed }),
+
+	stringSetting("linera-endpoint", "LINERA_ENDPOINT", "Linera node service URL", func(c *Config) *string { return &c.Linera.Endpoint }),
+	boolSetting("linera-enabled", "LINERA_ENABLED", "mirror markets and bets to Linera", func(c *Config) *bool { return &c.Linera.Enabled }),
+
+	stringSetting("oracle-rules", "ORACLE_RULES", "YAML or TOML file of oracle market generation rules", func(c *Config) *string { return &c.Oracle.RulesFile }),
+
+	floatSetting("bet-min", "BET_MIN", "smallest accepted bet (0 disables)", func(c *Config) *float64 { return &c.Bets.Min }),
+	floatSetting("bet-max", "BET_MAX", "largest accepted bet (0 disables)", func(c *Config) *float64 { return &c.Bets.Max }),
+	floatSetting("bet-max-exposure", "BET_MAX_EXPOSURE", "most a user may stake on one market (0 disables)", func(c *Config) *float64 { return &c.Bets.MaxExposure }),
+	floatSetting("bet-max-pool-imbalance", "BET_MAX_POOL_IMBALANCE", "largest allowed gap between the Yes and No pools (0 disables)", func(c *Config) *float64 { return &c.Bets.MaxPoolImbalance }),
+
+	durationSetting("leaderboard-refresh-interval", "LEADERBOARD_REFRESH_INTERVAL", "how often the leaderboard is rebuilt", func(c *Config) *time.Duration { return &c.Leaderboard.RefreshInterval }),
+}
+
+// Load builds the configuration from the defaults, the file named by -config
+// or CONFIG_FILE, the environment and the flags in args, then validates it.
+// It returns the arguments left after the flags. Empty environment variables
+// are ignored. With -h it prints the flags and returns flag.ErrHelp.
+func Load(args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	file := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML configuration file (env CONFIG_FILE)")
+
+	flagValues := make(map[string]string)
+	for _, s := range settings {
+		name := s.flag
+		usage := fmt.Sprintf("%s (env %s)", s.usage, s.env)
+		record := func(raw string) error {
+			flagValues[name] = raw
+			return nil
+		}
+		if s.isBool {
+			fs.BoolFunc(name, usage, record)
+		} else {
+			fs.Func(name, usage, record)
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	cfg := Default()
+	if *file != "" {
+		if err := DecodeFile(*file, cfg); err != nil {
+			return nil, nil, err
+		}
+		log.Printf("⚙️  Loaded configuration from %s", *file)
+	}
+
+	for _, s := range settings {
+		raw := os.Getenv(s.env)
+		if raw == "" {
+			continue
+		}
+		if err := s.set(cfg, raw); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", s.env, err)
+		}
+	}
+
+	for _, s := range settings {
+		raw, ok := flagValues[s.flag]
+		if !ok {
+			continue
+		}
+		if err := s.set(cfg, raw); err != nil {
+			return nil, nil, fmt.Errorf("invalid -%s: %w", s.flag, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+// DecodeFile decodes a .yaml, .yml or .toml file into v, overriding only the
+// values present in the file. Unknown keys are rejected so typos do not go
+// unnoticed.
+func DecodeFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), v)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("failed to parse %s: unknown keys %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("config file %s must end in .yaml, .yml or .toml", path)
+	}
+	return nil
+}
+
+func stringSetting(flag, env, usage string, field func(*Config) *string) setting {
+	return setting{flag: flag, env: env, usage: usage, set: func(c *Config, raw string) error {
+		*field(c) = raw
+		return nil
+	}}
+}
+
+func listSetting(flag, env, usage string, field func(*Config) *[]string) setting {
+	return setting{flag: flag, env: env, usage: usage, set: func(c *Config, raw string) error {
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*field(c) = items
+		return nil
+	}}
+}
+
+func boolSetting(flag, env, usage string, field func(*Config) *bool) setting {
+	return setting{flag: flag, env: env, usage: usage, isBool: true, set: func(c *Config, raw string) error {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got %q", raw)
+		}
+		*field(c) = v
+		return nil
+	}}
+}
+
+func durationSetting(flag, env, usage string, field func(*Config) *time.Duration) setting {
+	return setting{flag: flag, env: env, usage: usage, set: func(c *Config, raw string) error {
+		v, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("expected a duration such as 5m, got %q", raw)
+		}
+		*field(c) = v
+		return nil
+	}}
+}
+
+func floatSetting(flag, env, usage string, field func(*Config) *float64) setting {
+	return setting{flag: flag, env: env, usage: usage, set: func(c *Config, raw string) error {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("expected a number, got %q", raw)
+		}
+		*field(c) = v
+		return nil
+	}}
+}
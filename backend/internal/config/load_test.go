@@ -0,0 +1,170 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string // name of the config file written from content, if any
+		content     string
+		fileFromEnv bool // pass the file in CONFIG_FILE instead of -config
+		env         map[string]string
+		args        []string
+		wantAddr    string
+		wantRefresh time.Duration
+		wantArgs    []string
+		wantErr     string
+	}{
+		{
+			name:        "defaults",
+			wantAddr:    ":3001",
+			wantRefresh: 5 * time.Minute,
+		},
+		{
+			name:        "YAML file overrides defaults",
+			file:        "config.yaml",
+			content:     "server:\n  addr: \":4000\"\nleaderboard:\n  refresh_interval: 1m\n",
+			wantAddr:    ":4000",
+			wantRefresh: time.Minute,
+		},
+		{
+			name:        "TOML file overrides defaults",
+			file:        "config.toml",
+			content:     "[server]\naddr = \":4000\"\n",
+			wantAddr:    ":4000",
+			wantRefresh: 5 * time.Minute,
+		},
+		{
+			name:        "file named by CONFIG_FILE",
+			file:        "config.yaml",
+			content:     "server:\n  addr: \":4000\"\n",
+			fileFromEnv: true,
+			wantAddr:    ":4000",
+			wantRefresh: 5 * time.Minute,
+		},
+		{
+			name:        "environment overrides file",
+			file:        "config.yaml",
+			content:     "server:\n  addr: \":4000\"\nleaderboard:\n  refresh_interval: 1m\n",
+			env:         map[string]string{"HTTP_ADDR": ":5000"},
+			wantAddr:    ":5000",
+			wantRefresh: time.Minute,
+		},
+		{
+			name:        "empty environment variable is ignored",
+			file:        "config.yaml",
+			content:     "server:\n  addr: \":4000\"\n",
+			env:         map[string]string{"HTTP_ADDR": ""},
+			wantAddr:    ":4000",
+			wantRefresh: 5 * time.Minute,
+		},
+		{
+			name:        "flag overrides environment",
+			file:        "config.yaml",
+			content:     "server:\n  addr: \":4000\"\n",
+			env:         map[string]string{"HTTP_ADDR": ":5000", "LEADERBOARD_REFRESH_INTERVAL": "2m"},
+			args:        []string{"-addr", ":6000"},
+			wantAddr:    ":6000",
+			wantRefresh: 2 * time.Minute,
+		},
+		{
+			name:        "arguments after the flags are returned",
+			args:        []string{"-addr", ":6000", "status"},
+			wantAddr:    ":6000",
+			wantRefresh: 5 * time.Minute,
+			wantArgs:    []string{"status"},
+		},
+		{
+			name:    "unknown YAML key",
+			file:    "config.yaml",
+			content: "server:\n  adress: \":4000\"\n",
+			wantErr: "adress",
+		},
+		{
+			name:    "unknown TOML key",
+			file:    "config.toml",
+			content: "[server]\nadress = \":4000\"\n",
+			wantErr: "unknown keys [server.adress]",
+		},
+		{
+			name:    "unsupported file type",
+			file:    "config.json",
+			content: "{}",
+			wantErr: "must end in .yaml, .yml or .toml",
+		},
+		{
+			name:    "invalid environment value",
+			env:     map[string]string{"BET_MIN": "one"},
+			wantErr: "invalid BET_MIN",
+		},
+		{
+			name:    "invalid flag value",
+			args:    []string{"-shutdown-timeout", "soon"},
+			wantErr: "invalid -shutdown-timeout",
+		},
+		{
+			name:    "invalid result",
+			env:     map[string]string{"STORAGE": "mongo"},
+			wantErr: "storage.backend must be postgres, sqlite or memory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Settings from the environment the tests run in must not leak in
+			t.Setenv("CONFIG_FILE", "")
+			for _, s := range settings {
+				t.Setenv(s.env, "")
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			args := tt.args
+			if tt.file != "" {
+				path := filepath.Join(t.TempDir(), tt.file)
+				if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+					t.Fatalf("failed to write config file: %v", err)
+				}
+				if tt.fileFromEnv {
+					t.Setenv("CONFIG_FILE", path)
+				} else {
+					args = append([]string{"-config", path}, args...)
+				}
+			}
+
+			cfg, rest, err := Load(args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			if cfg.Server.Addr != tt.wantAddr {
+				t.Errorf("server.addr = %q, want %q", cfg.Server.Addr, tt.wantAddr)
+			}
+			if cfg.Leaderboard.RefreshInterval != tt.wantRefresh {
+				t.Errorf("leaderboard.refresh_interval = %v, want %v", cfg.Leaderboard.RefreshInterval, tt.wantRefresh)
+			}
+			if cfg.Server.GRPCAddr != Default().Server.GRPCAddr {
+				t.Errorf("server.grpc_addr = %q, want the default it was never given", cfg.Server.GRPCAddr)
+			}
+			if len(rest) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(rest, tt.wantArgs) {
+					t.Errorf("remaining arguments = %v, want %v", rest, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
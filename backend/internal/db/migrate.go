@@ -0,0 +1,263 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey is the pg_advisory_lock key held while migrations run
+const migrationLockKey = 7239410581
+
+// migrationDirs maps each driver to its migration directory
+var migrationDirs = map[string]string{
+	DriverPostgres: "migrations/postgres",
+	DriverSQLite:   "migrations/sqlite",
+}
+
+var migrationName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a numbered schema change with its up and down SQL
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// LoadMigrations reads the embedded migrations for a driver ordered by version
+func LoadMigrations(driver string) ([]*Migration, error) {
+	dir, ok := migrationDirs[driver]
+	if !ok {
+		return nil, fmt.Errorf("no migrations for driver %q", driver)
+	}
+
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		body, err := migrationFiles.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names: %s and %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s is missing its up or down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Migrate applies every pending migration
+func (db *DB) Migrate() error {
+	return db.MigrateUp(0)
+}
+
+// MigrateUp applies up to steps pending migrations (0 applies all of them)
+func (db *DB) MigrateUp(steps int) error {
+	migrations, err := LoadMigrations(db.Driver)
+	if err != nil {
+		return err
+	}
+
+	return db.withMigrationLock(func(conn *sql.Conn, applied map[int]time.Time) error {
+		count := 0
+		for _, m := range migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			if steps > 0 && count >= steps {
+				break
+			}
+
+			if err := runMigration(conn, m.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %w", m.Version, m.Name, err)
+			}
+			log.Printf("⬆️  Applied migration %04d_%s", m.Version, m.Name)
+			count++
+		}
+
+		if count == 0 {
+			log.Println("📦 Database schema is up to date")
+		}
+		return nil
+	})
+}
+
+// MigrateDown reverts the most recently applied migrations (steps must be at least 1)
+func (db *DB) MigrateDown(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1")
+	}
+
+	migrations, err := LoadMigrations(db.Driver)
+	if err != nil {
+		return err
+	}
+
+	return db.withMigrationLock(func(conn *sql.Conn, applied map[int]time.Time) error {
+		count := 0
+		for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+			m := migrations[i]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+
+			if err := runMigration(conn, m.Down, `DELETE FROM schema_migrations WHERE version = $1`, m.Version); err != nil {
+				return fmt.Errorf("rollback of %04d_%s failed: %w", m.Version, m.Name, err)
+			}
+			log.Printf("⬇️  Reverted migration %04d_%s", m.Version, m.Name)
+			count++
+		}
+
+		if count == 0 {
+			log.Println("📦 No migrations to revert")
+		}
+		return nil
+	})
+}
+
+// MigrationStatuses lists every known migration and whether it has been applied
+func (db *DB) MigrationStatuses() ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations(db.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	err = db.withMigrationLock(func(conn *sql.Conn, applied map[int]time.Time) error {
+		for _, m := range migrations {
+			status := MigrationStatus{Version: m.Version, Name: m.Name}
+			if at, ok := applied[m.Version]; ok {
+				status.Applied = true
+				status.AppliedAt = &at
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withMigrationLock runs fn on a dedicated connection holding the migration
+// advisory lock, so concurrent server instances never migrate at the same time.
+// SQLite has no advisory locks; its single-writer model and the
+// schema_migrations primary key stop a second runner from applying a
+// migration twice.
+func (db *DB) withMigrationLock(fn func(conn *sql.Conn, applied map[int]time.Time) error) error {
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if db.Driver == DriverPostgres {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationLockKey)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		applied[version] = at
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	return fn(conn, applied)
+}
+
+// runMigration executes a migration body and records it in one transaction
+func runMigration(conn *sql.Conn, body, record string, args ...interface{}) error {
+	ctx := context.Background()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS user_balance;
+DROP TABLE IF EXISTS user_positions;
+DROP TABLE IF EXISTS markets;
+DROP FUNCTION IF EXISTS update_updated_at_column();
@@ -1,7 +1,6 @@
--- Predictum Database Schema
--- PostgreSQL 15+
+-- Core tables. Uses IF NOT EXISTS so databases created from the old
+-- db/schema.sql script can be adopted by the migration runner.
 
--- Markets table
 CREATE TABLE IF NOT EXISTS markets (
     id SERIAL PRIMARY KEY,
     question TEXT NOT NULL,
@@ -16,7 +15,6 @@ CREATE TABLE IF NOT EXISTS markets (
     created_at TIMESTAMP DEFAULT NOW()
 );
 
--- User positions table
 CREATE TABLE IF NOT EXISTS user_positions (
     id SERIAL PRIMARY KEY,
     market_id INT NOT NULL REFERENCES markets(id) ON DELETE CASCADE,
@@ -37,17 +35,14 @@ CREATE TABLE IF NOT EXISTS user_balance (
     CHECK (id = 1)
 );
 
--- Insert default balance
 INSERT INTO user_balance (id, balance) VALUES (1, 10000)
 ON CONFLICT (id) DO NOTHING;
 
--- Indexes for performance
 CREATE INDEX IF NOT EXISTS idx_markets_status ON markets(status);
 CREATE INDEX IF NOT EXISTS idx_markets_end_time ON markets(end_time);
 CREATE INDEX IF NOT EXISTS idx_markets_created_at ON markets(created_at);
 CREATE INDEX IF NOT EXISTS idx_user_positions_market_id ON user_positions(market_id);
 
--- Trigger to update updated_at timestamp
 CREATE OR REPLACE FUNCTION update_updated_at_column()
 RETURNS TRIGGER AS $$
 BEGIN
@@ -56,9 +51,8 @@ BEGIN
 END;
 $$ language 'plpgsql';
 
-CREATE TRIGGER update_user_positions_updated_at BEFORE UPDATE ON user_positions
+CREATE OR REPLACE TRIGGER update_user_positions_updated_at BEFORE UPDATE ON user_positions
 FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
-CREATE TRIGGER update_user_balance_updated_at BEFORE UPDATE ON user_balance
+CREATE OR REPLACE TRIGGER update_user_balance_updated_at BEFORE UPDATE ON user_balance
 FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-
@@ -0,0 +1,10 @@
+-- Domain event log (sequence numbers back SSE Last-Event-ID resume)
+CREATE TABLE IF NOT EXISTS events (
+    seq BIGSERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    market_id INT,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS market_price_history;
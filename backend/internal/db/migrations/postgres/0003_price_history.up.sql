@@ -0,0 +1,16 @@
+-- Market price history (raw per-bet snapshots, downsampled into buckets over time)
+CREATE TABLE IF NOT EXISTS market_price_history (
+    id BIGSERIAL PRIMARY KEY,
+    market_id INT NOT NULL REFERENCES markets(id) ON DELETE CASCADE,
+    bucket_start TIMESTAMP NOT NULL,
+    resolution_seconds INT NOT NULL DEFAULT 0,
+    open DOUBLE PRECISION NOT NULL,
+    high DOUBLE PRECISION NOT NULL,
+    low DOUBLE PRECISION NOT NULL,
+    close DOUBLE PRECISION NOT NULL,
+    volume DECIMAL(20,2) DEFAULT 0,
+    yes_pool DECIMAL(20,2) DEFAULT 0,
+    no_pool DECIMAL(20,2) DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_market_price_history_market_time ON market_price_history(market_id, bucket_start);
@@ -0,0 +1,14 @@
+-- Individual bets (the trade tape); user_positions holds the aggregate
+CREATE TABLE IF NOT EXISTS trades (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(100) NOT NULL,
+    market_id INT NOT NULL REFERENCES markets(id) ON DELETE CASCADE,
+    outcome VARCHAR(10) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    shares DECIMAL(20,2) NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trades_market_id ON trades(market_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_trades_user_id ON trades(user_id, id DESC);
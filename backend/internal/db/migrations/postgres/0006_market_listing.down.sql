@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_markets_question;
+DROP INDEX IF EXISTS idx_markets_volume;
+DROP INDEX IF EXISTS idx_markets_created_at_id;
+DROP INDEX IF EXISTS idx_markets_category_end_time;
+DROP INDEX IF EXISTS idx_markets_status_end_time;
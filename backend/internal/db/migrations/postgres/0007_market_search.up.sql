@@ -0,0 +1,8 @@
+-- Full-text search over market questions. The 'simple' configuration indexes
+-- words as written, without English stopwords or stemming, so search matches
+-- the same literal prefixes as the SQLite and in-memory backends
+ALTER TABLE markets
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', question)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_markets_search_vector ON markets USING GIN (search_vector);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_markets_active_question;
+ALTER TABLE markets DROP COLUMN IF EXISTS resolution_source;
@@ -0,0 +1,5 @@
+-- Where a market's outcome is looked up: an http(s) URL or "oracle:<name>"
+ALTER TABLE markets ADD COLUMN resolution_source TEXT NOT NULL DEFAULT '';
+
+-- Duplicate-question check on market creation
+CREATE INDEX IF NOT EXISTS idx_markets_active_question ON markets (LOWER(question)) WHERE status = 'Active';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_balance;
+DROP TABLE IF EXISTS user_positions;
+DROP TABLE IF EXISTS markets;
@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS markets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    question TEXT NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'Active',
+    end_time TIMESTAMP NOT NULL,
+    yes_pool DECIMAL(20,2) DEFAULT 0,
+    no_pool DECIMAL(20,2) DEFAULT 0,
+    total_yes_shares DECIMAL(20,2) DEFAULT 0,
+    total_no_shares DECIMAL(20,2) DEFAULT 0,
+    winning_outcome VARCHAR(10),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_positions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    market_id INTEGER NOT NULL REFERENCES markets(id) ON DELETE CASCADE,
+    yes_shares DECIMAL(20,2) DEFAULT 0,
+    no_shares DECIMAL(20,2) DEFAULT 0,
+    yes_amount DECIMAL(20,2) DEFAULT 0,
+    no_amount DECIMAL(20,2) DEFAULT 0,
+    claimed BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- User balance table (single row for demo)
+CREATE TABLE IF NOT EXISTS user_balance (
+    id INTEGER PRIMARY KEY DEFAULT 1,
+    balance DECIMAL(20,2) DEFAULT 10000,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (id = 1)
+);
+
+INSERT INTO user_balance (id, balance) VALUES (1, 10000)
+ON CONFLICT (id) DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_markets_status ON markets(status);
+CREATE INDEX IF NOT EXISTS idx_markets_end_time ON markets(end_time);
+CREATE INDEX IF NOT EXISTS idx_markets_created_at ON markets(created_at);
+CREATE INDEX IF NOT EXISTS idx_user_positions_market_id ON user_positions(market_id);
+
+CREATE TRIGGER IF NOT EXISTS update_user_positions_updated_at AFTER UPDATE ON user_positions
+FOR EACH ROW BEGIN
+    UPDATE user_positions SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_user_balance_updated_at AFTER UPDATE ON user_balance
+FOR EACH ROW BEGIN
+    UPDATE user_balance SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
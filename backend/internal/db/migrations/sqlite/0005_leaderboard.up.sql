@@ -0,0 +1,15 @@
+-- Materialized leaderboard, rebuilt periodically from trades
+CREATE TABLE IF NOT EXISTS leaderboard (
+    period VARCHAR(10) NOT NULL,
+    user_id VARCHAR(100) NOT NULL,
+    volume DECIMAL(20,2) DEFAULT 0,
+    trades INT DEFAULT 0,
+    resolved_markets INT DEFAULT 0,
+    wins INT DEFAULT 0,
+    realized_profit DECIMAL(20,2) DEFAULT 0,
+    win_rate REAL DEFAULT 0,
+    roi REAL DEFAULT 0,
+    brier_score REAL,
+    refreshed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (period, user_id)
+);
@@ -0,0 +1,6 @@
+-- Indexes backing the filters and sort orders of GET /api/markets
+CREATE INDEX IF NOT EXISTS idx_markets_status_end_time ON markets(status, end_time, id);
+CREATE INDEX IF NOT EXISTS idx_markets_category_end_time ON markets(category, end_time, id);
+CREATE INDEX IF NOT EXISTS idx_markets_created_at_id ON markets(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_markets_volume ON markets((yes_pool + no_pool) DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_markets_question ON markets(question, id);
@@ -0,0 +1,3 @@
+-- SQLite has no tsvector: market search pre-filters questions with LIKE and
+-- ranks the matches in Go. Kept so both dialects share version numbers.
+SELECT 1;
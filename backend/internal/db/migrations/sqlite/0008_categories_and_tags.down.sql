@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS market_tags;
+DROP TABLE IF EXISTS categories;
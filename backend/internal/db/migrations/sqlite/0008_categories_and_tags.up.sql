@@ -0,0 +1,26 @@
+-- Category hierarchy; markets.category holds the category name
+CREATE TABLE IF NOT EXISTS categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    parent_id INTEGER REFERENCES categories(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
+
+INSERT INTO categories (name) VALUES ('Crypto'), ('Sports'), ('Binary')
+ON CONFLICT (name) DO NOTHING;
+
+-- Register any free-form categories already used by markets
+INSERT INTO categories (name)
+SELECT DISTINCT category FROM markets WHERE true
+ON CONFLICT (name) DO NOTHING;
+
+-- Free-form tags, several per market
+CREATE TABLE IF NOT EXISTS market_tags (
+    market_id INTEGER NOT NULL REFERENCES markets(id) ON DELETE CASCADE,
+    tag VARCHAR(32) NOT NULL,
+    PRIMARY KEY (market_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_market_tags_tag ON market_tags(tag, market_id);
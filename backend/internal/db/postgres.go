@@ -10,8 +10,14 @@ import (
 	_ "github.com/lib/pq"
 )
 
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite3"
+)
+
 type DB struct {
 	*sql.DB
+	Driver string
 }
 
 // Connect establishes a connection to PostgreSQL
@@ -32,7 +38,7 @@ func Connect(databaseURL string) (*DB, error) {
 	}
 
 	log.Println("✅ Connected to PostgreSQL database")
-	return &DB{db}, nil
+	return &DB{DB: db, Driver: DriverPostgres}, nil
 }
 
 // Close closes the database connection
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// ConnectSQLite opens (creating if needed) an embedded SQLite database file.
+// Use ":memory:" for a throwaway in-process database.
+func ConnectSQLite(path string) (*DB, error) {
+	dsn := fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000", path)
+	if path != ":memory:" {
+		dsn += "&_journal_mode=WAL"
+	}
+
+	db, err := sql.Open(DriverSQLite, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY and
+	// keeps ":memory:" databases from being split across connections
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	log.Printf("✅ Connected to SQLite database: %s", path)
+	return &DB{DB: db, Driver: DriverSQLite}, nil
+}
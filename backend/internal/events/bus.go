@@ -0,0 +1,177 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+const (
+	// DefaultBufferSize is the number of events buffered per subscriber
+	DefaultBufferSize = 64
+
+	// persistTimeout bounds how long a publisher waits on the store, since
+	// every other publisher is queued behind it
+	persistTimeout = 5 * time.Second
+)
+
+// Store persists events so that clients can resume from a sequence number
+type Store interface {
+	AppendEvent(ctx context.Context, event *models.Event) error
+	GetEventsSince(ctx context.Context, afterSeq int64, limit int) ([]models.Event, error)
+}
+
+// Bus is an in-process publish/subscribe hub for domain events
+type Bus struct {
+	store       Store
+	publishMu   sync.Mutex
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// Subscription receives events published on the bus until it is closed
+type Subscription struct {
+	C <-chan models.Event
+
+	ch     chan models.Event
+	bus    *Bus
+	once   sync.Once
+	closed chan struct{}
+}
+
+// NewBus creates a new event bus. If store is non-nil every published event
+// is persisted first and receives its sequence number from the store.
+func NewBus(store Store) *Bus {
+	return &Bus{
+		store:       store,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish delivers an event to every subscriber.
+// Subscribers that are not keeping up have the event dropped rather than
+// blocking the publisher.
+func (b *Bus) Publish(event models.Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	// Serialize publishers so subscribers observe sequence numbers in order
+	b.publishMu.Lock()
+	defer b.publishMu.Unlock()
+
+	// Persist with a fresh context: the change the event announces has
+	// already happened, even if the request that made it was cancelled
+	if b.store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), persistTimeout)
+		err := b.store.AppendEvent(ctx, &event)
+		cancel()
+		if err != nil {
+			log.Printf("⚠️  Failed to persist %s event: %v", event.Type, err)
+		}
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			log.Printf("⚠️  Event bus subscriber is full, dropping %s event for market #%d", event.Type, event.MarketID)
+		}
+	}
+}
+
+// EventsSince returns persisted events with a sequence number greater than afterSeq
+func (b *Bus) EventsSince(ctx context.Context, afterSeq int64, limit int) ([]models.Event, error) {
+	if b.store == nil {
+		return nil, nil
+	}
+	return b.store.GetEventsSince(ctx, afterSeq, limit)
+}
+
+// Subscribe registers a new subscriber with the given buffer size
+func (b *Bus) Subscribe(buffer int) *Subscription {
+	if buffer <= 0 {
+		buffer = DefaultBufferSize
+	}
+
+	ch := make(chan models.Event, buffer)
+	sub := &Subscription{
+		C:      ch,
+		ch:     ch,
+		bus:    b,
+		closed: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// A closed bus hands out subscriptions that are already done
+	if b.closed {
+		sub.once.Do(func() { close(sub.closed) })
+		return sub
+	}
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Close ends every subscription so streaming clients disconnect, e.g. on
+// shutdown. Events published afterwards are still persisted.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	subs := make([]*Subscription, 0, len(b.subscribers))
+	for sub := range b.subscribers {
+		subs = append(subs, sub)
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
+
+// Close unregisters the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.bus.mu.Lock()
+		delete(s.bus.subscribers, s)
+		s.bus.mu.Unlock()
+		close(s.closed)
+	})
+}
+
+// Done is closed once the subscription has been closed
+func (s *Subscription) Done() <-chan struct{} {
+	return s.closed
+}
+
+// BalanceEvent builds an event announcing the user's new balance
+func BalanceEvent(marketID int, balance float64) models.Event {
+	return models.Event{
+		Type:      models.EventBalance,
+		MarketID:  marketID,
+		Balance:   &balance,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// MarketEvent builds an event carrying a snapshot of the market and its odds
+func MarketEvent(eventType models.EventType, market *models.Market) models.Event {
+	snapshot := *market
+	odds := models.CalculateOdds(&snapshot)
+
+	return models.Event{
+		Type:      eventType,
+		MarketID:  snapshot.ID,
+		Market:    &snapshot,
+		Odds:      &odds,
+		Timestamp: time.Now().UTC(),
+	}
+}
@@ -0,0 +1,181 @@
+// Package fixtures holds named sets of demo data. Nothing is seeded unless a
+// set is asked for by name, with the seed command or the seed setting.
+package fixtures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// Store defines the storage operations used to seed a fixture set
+type Store interface {
+	GetMarkets(ctx context.Context) ([]*models.Market, error)
+	SaveMarket(ctx context.Context, market *models.Market) error
+	SavePosition(ctx context.Context, position *models.UserPosition) error
+}
+
+// ErrNotEmpty is returned when seeding a store that already has markets
+var ErrNotEmpty = errors.New("storage already has markets")
+
+// Set is a named group of demo markets and the default user's positions on them
+type Set struct {
+	Description string
+	Markets     func(now time.Time) []*models.Market
+	Positions   []Position
+}
+
+// Position is a holding on the market at index Market of its set
+type Position struct {
+	Market   int
+	Position models.UserPosition
+}
+
+// Sets lists every fixture set by name
+var Sets = map[string]Set{
+	"demo": {
+		Description: "six sports and crypto markets with positions for the default user",
+		Markets:     demoMarkets,
+		Positions:   demoPositions,
+	},
+	"markets": {
+		Description: "the demo markets without any positions",
+		Markets:     demoMarkets,
+	},
+}
+
+// Names returns the fixture set names in order
+func Names() []string {
+	names := make([]string, 0, len(Sets))
+	for name := range Sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Seed saves the named set into a store without markets and reports how many
+// markets and positions it saved. It returns ErrNotEmpty, and saves nothing,
+// if the store already has markets.
+func Seed(ctx context.Context, s Store, name string) (markets, positions int, err error) {
+	set, ok := Sets[name]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown fixture set %q", name)
+	}
+
+	existing, err := s.GetMarkets(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count markets: %w", err)
+	}
+	if len(existing) > 0 {
+		return 0, 0, ErrNotEmpty
+	}
+
+	saved := set.Markets(time.Now())
+	for _, market := range saved {
+		if err := s.SaveMarket(ctx, market); err != nil {
+			return markets, positions, fmt.Errorf("failed to save fixture market: %w", err)
+		}
+		markets++
+	}
+
+	for _, p := range set.Positions {
+		position := p.Position
+		position.MarketID = saved[p.Market].ID
+		if err := s.SavePosition(ctx, &position); err != nil {
+			return markets, positions, fmt.Errorf("failed to save fixture position: %w", err)
+		}
+		positions++
+	}
+
+	return markets, positions, nil
+}
+
+// demoMarkets returns the demo markets
+func demoMarkets(now time.Time) []*models.Market {
+	return []*models.Market{
+		{
+			Question:         "Will Manchester City win the Premier League 2025-26?",
+			Category:         "Sports",
+			ResolutionSource: "https://www.premierleague.com/tables",
+			Status:           models.StatusActive,
+			EndTime:          time.Date(2026, 5, 23, 20, 0, 0, 0, time.UTC),
+			YesPool:          1250,
+			NoPool:           850,
+			TotalYesShares:   1250000,
+			TotalNoShares:    850000,
+			CreatedAt:        now.Add(-24 * time.Hour),
+		},
+		{
+			Question:         "Will Bitcoin reach $100,000 by December 31, 2025?",
+			Category:         "Crypto",
+			ResolutionSource: "oracle:coingecko",
+			Status:           models.StatusActive,
+			EndTime:          time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
+			YesPool:          3400,
+			NoPool:           2100,
+			TotalYesShares:   3400000,
+			TotalNoShares:    2100000,
+			CreatedAt:        now.Add(-48 * time.Hour),
+		},
+		{
+			Question:         "Will Lakers make it to NBA playoffs this season?",
+			Category:         "Sports",
+			ResolutionSource: "https://www.nba.com/standings",
+			Status:           models.StatusActive,
+			EndTime:          time.Date(2026, 4, 15, 23, 59, 59, 0, time.UTC),
+			YesPool:          890,
+			NoPool:           1560,
+			TotalYesShares:   890000,
+			TotalNoShares:    1560000,
+			CreatedAt:        now.Add(-36 * time.Hour),
+		},
+		{
+			Question:         "Will Ethereum price be above $5,000 by end of November 2025?",
+			Category:         "Crypto",
+			ResolutionSource: "oracle:coingecko",
+			Status:           models.StatusActive,
+			EndTime:          time.Date(2025, 11, 30, 23, 59, 59, 0, time.UTC),
+			YesPool:          2200,
+			NoPool:           1800,
+			TotalYesShares:   2200000,
+			TotalNoShares:    1800000,
+			CreatedAt:        now.Add(-12 * time.Hour),
+		},
+		{
+			Question:         "Will Real Madrid win their next La Liga match?",
+			Category:         "Sports",
+			ResolutionSource: "https://www.laliga.com/en-GB/laliga-easports/results",
+			Status:           models.StatusActive,
+			EndTime:          now.Add(72 * time.Hour),
+			YesPool:          1800,
+			NoPool:           900,
+			TotalYesShares:   1800000,
+			TotalNoShares:    900000,
+			CreatedAt:        now.Add(-6 * time.Hour),
+		},
+		{
+			Question:         "Will Bitcoin price be above $95,000 in 48 hours?",
+			Category:         "Crypto",
+			ResolutionSource: "oracle:coingecko",
+			Status:           models.StatusActive,
+			EndTime:          now.Add(48 * time.Hour),
+			YesPool:          1500,
+			NoPool:           2500,
+			TotalYesShares:   1500000,
+			TotalNoShares:    2500000,
+			CreatedAt:        now.Add(-3 * time.Hour),
+		},
+	}
+}
+
+// demoPositions are the default user's positions on the demo markets
+var demoPositions = []Position{
+	{Market: 0, Position: models.UserPosition{YesShares: 50000, YesAmount: 50}},
+	{Market: 1, Position: models.UserPosition{YesShares: 100000, YesAmount: 100}},
+	{Market: 5, Position: models.UserPosition{NoShares: 80000, NoAmount: 80}},
+}
@@ -0,0 +1,38 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	pb "github.com/linera-prediction-market/backend/proto/predictum/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AdminAuth requires calls to the AdminService to carry token in an
+// "authorization: Bearer <token>" metadata entry, the header the REST admin
+// routes check. An empty token leaves the service open.
+func AdminAuth(token string) grpc.UnaryServerInterceptor {
+	prefix := "/" + pb.AdminService_ServiceDesc.ServiceName + "/"
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if token != "" && strings.HasPrefix(info.FullMethod, prefix) && !hasToken(ctx, token) {
+			return nil, status.Error(codes.Unauthenticated, "admin token required")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func hasToken(ctx context.Context, token string) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		given, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminAuth(t *testing.T) {
+	const (
+		resolve = "/predictum.v1.AdminService/ResolveMarket"
+		bet     = "/predictum.v1.TradingService/PlaceBet"
+	)
+	tests := []struct {
+		name          string
+		token         string
+		method        string
+		authorization string
+		wantCode      codes.Code
+	}{
+		{name: "no token set", method: resolve, wantCode: codes.OK},
+		{name: "right token", token: "s3cret", method: resolve, authorization: "Bearer s3cret", wantCode: codes.OK},
+		{name: "missing token", token: "s3cret", method: resolve, wantCode: codes.Unauthenticated},
+		{name: "wrong token", token: "s3cret", method: resolve, authorization: "Bearer guess", wantCode: codes.Unauthenticated},
+		{name: "other services stay open", token: "s3cret", method: bet, wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+			_, err := AdminAuth(tt.token)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package grpcapi
+
+import (
+	"strconv"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+	pb "github.com/linera-prediction-market/backend/proto/predictum/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var statuses = map[models.MarketStatus]pb.MarketStatus{
+	models.StatusActive:    pb.MarketStatus_MARKET_STATUS_ACTIVE,
+	models.StatusLocked:    pb.MarketStatus_MARKET_STATUS_LOCKED,
+	models.StatusResolved:  pb.MarketStatus_MARKET_STATUS_RESOLVED,
+	models.StatusCancelled: pb.MarketStatus_MARKET_STATUS_CANCELLED,
+}
+
+var outcomes = map[models.Outcome]pb.Outcome{
+	models.OutcomeYes: pb.Outcome_OUTCOME_YES,
+	models.OutcomeNo:  pb.Outcome_OUTCOME_NO,
+}
+
+func toMarket(market *models.Market) *pb.Market {
+	odds := models.CalculateOdds(market)
+	m := &pb.Market{
+		Id:               int64(market.ID),
+		Question:         market.Question,
+		Category:         market.Category,
+		Status:           statuses[market.Status],
+		EndTime:          timestamppb.New(market.EndTime),
+		YesPool:          market.YesPool,
+		NoPool:           market.NoPool,
+		TotalYesShares:   market.TotalYesShares,
+		TotalNoShares:    market.TotalNoShares,
+		ResolutionSource: market.ResolutionSource,
+		Tags:             market.Tags,
+		CreatedAt:        timestamppb.New(market.CreatedAt),
+		Odds:             &pb.Odds{Yes: odds.Yes, No: odds.No},
+	}
+	if market.WinningOutcome != nil {
+		m.WinningOutcome = outcomes[*market.WinningOutcome]
+	}
+	return m
+}
+
+func toEvent(event models.Event) *pb.MarketEvent {
+	return &pb.MarketEvent{
+		Seq:       event.Seq,
+		Type:      string(event.Type),
+		MarketId:  int64(event.MarketID),
+		Market:    toMarket(event.Market),
+		Timestamp: timestamppb.New(event.Timestamp),
+	}
+}
+
+func fromOutcome(outcome pb.Outcome) (models.Outcome, error) {
+	switch outcome {
+	case pb.Outcome_OUTCOME_YES:
+		return models.OutcomeYes, nil
+	case pb.Outcome_OUTCOME_NO:
+		return models.OutcomeNo, nil
+	default:
+		return "", invalidArgument("invalid_outcome", "outcome must be OUTCOME_YES or OUTCOME_NO")
+	}
+}
+
+// marketQuery checks a ListMarkets request and converts it to a storage query
+func marketQuery(req *pb.ListMarketsRequest) (models.MarketQuery, error) {
+	query := models.MarketQuery{
+		Category: req.GetCategory(),
+		Tag:      req.GetTag(),
+		SortBy:   req.GetSortBy(),
+		Limit:    int(req.GetPageSize()),
+	}
+
+	if req.GetStatus() != pb.MarketStatus_MARKET_STATUS_UNSPECIFIED {
+		for status, value := range statuses {
+			if value == req.GetStatus() {
+				query.Status = string(status)
+			}
+		}
+		if query.Status == "" {
+			return query, invalidArgument("invalid_parameter", "unknown status")
+		}
+	}
+
+	if query.SortBy == "" {
+		query.SortBy = models.SortEndingSoon
+	}
+	valid := false
+	for _, sort := range models.MarketSorts {
+		valid = valid || sort == query.SortBy
+	}
+	if !valid {
+		return query, invalidArgument("invalid_parameter", "unknown sort order "+strconv.Quote(query.SortBy))
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+	if query.Limit < 0 || query.Limit > 100 {
+		return query, invalidArgument("invalid_parameter", "page size must be between 1 and 100")
+	}
+
+	if token := req.GetPageToken(); token != "" {
+		after, err := strconv.Atoi(token)
+		if err != nil || after <= 0 {
+			return query, invalidArgument("invalid_parameter", "invalid page token")
+		}
+		query.After = after
+	}
+
+	return query, nil
+}
+
+// pageToken is the ID of the last market on a page, like the REST cursor
+func pageToken(lastID int) string {
+	return strconv.Itoa(lastID)
+}
+
+func invalidArgument(code, message string) error {
+	return withCode(status.New(codes.InvalidArgument, message), code)
+}
@@ -0,0 +1,66 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/linera-prediction-market/backend/internal/market"
+	"github.com/linera-prediction-market/backend/internal/storage"
+	"github.com/linera-prediction-market/backend/internal/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to every error.
+// Its reason is the same stable code the HTTP API returns, e.g. bet_too_large.
+const ErrorDomain = "predictum"
+
+// toStatus converts a market service error to a gRPC status. Errors without
+// a code of their own are logged and reported with message.
+func toStatus(err error, message string) error {
+	var marketErr *market.Error
+	var betErr *validation.BetError
+	var fieldErrs validation.Errors
+
+	switch {
+	case errors.As(err, &marketErr):
+		code := codes.FailedPrecondition
+		switch {
+		case market.IsNotFound(marketErr):
+			code = codes.NotFound
+		case marketErr == market.ErrInvalidOutcome:
+			code = codes.InvalidArgument
+		}
+		return withCode(status.New(code, marketErr.Message), marketErr.Code)
+	case errors.As(err, &betErr):
+		code := codes.InvalidArgument
+		switch betErr.Code {
+		case validation.CodeMarketNotActive, validation.CodeInsufficientBalance,
+			validation.CodeExposureLimit, validation.CodePoolImbalance:
+			code = codes.FailedPrecondition
+		}
+		return withCode(status.New(code, betErr.Message), betErr.Code)
+	case errors.As(err, &fieldErrs):
+		return invalidArgument("validation_failed", fieldErrs.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case storage.IsUnavailable(err):
+		log.Printf("❌ gRPC: %s: %v", message, err)
+		return withCode(status.New(codes.Unavailable, message), "storage_unavailable")
+	case storage.IsConflict(err):
+		return withCode(status.New(codes.Aborted, message), "conflict")
+	default:
+		log.Printf("❌ gRPC: %s: %v", message, err)
+		return withCode(status.New(codes.Internal, message), "internal_error")
+	}
+}
+
+// withCode attaches the stable error code as an ErrorInfo detail
+func withCode(st *status.Status, code string) error {
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: code, Domain: ErrorDomain}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,160 @@
+// Package grpcapi serves the gRPC services defined in proto/predictum/v1 on
+// top of the market service, for trading bots and internal services.
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/linera-prediction-market/backend/internal/events"
+	"github.com/linera-prediction-market/backend/internal/models"
+	pb "github.com/linera-prediction-market/backend/proto/predictum/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MarketService defines the market rules the gRPC services expose
+type MarketService interface {
+	List(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error)
+	Get(ctx context.Context, id int) (*models.Market, error)
+	PlaceBet(ctx context.Context, user string, req models.BetRequest) (*models.BetResponse, error)
+	Claim(ctx context.Context, user string, marketID int) (*models.ClaimResponse, error)
+	Resolve(ctx context.Context, id int, outcome models.Outcome) (*models.Market, error)
+	Cancel(ctx context.Context, id int) (*models.Market, error)
+}
+
+// EventBus defines the event bus WatchMarkets streams from
+type EventBus interface {
+	Subscribe(buffer int) *events.Subscription
+}
+
+// Register adds the market, trading and admin services to s
+func Register(s *grpc.Server, m MarketService, e EventBus) {
+	pb.RegisterMarketServiceServer(s, &marketServer{markets: m, events: e})
+	pb.RegisterTradingServiceServer(s, &tradingServer{markets: m})
+	pb.RegisterAdminServiceServer(s, &adminServer{markets: m})
+}
+
+type marketServer struct {
+	pb.UnimplementedMarketServiceServer
+	markets MarketService
+	events  EventBus
+}
+
+func (s *marketServer) ListMarkets(ctx context.Context, req *pb.ListMarketsRequest) (*pb.ListMarketsResponse, error) {
+	query, err := marketQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := query.Limit
+	query.Limit++ // one extra row tells us whether another page exists
+
+	page, err := s.markets.List(ctx, query)
+	if err != nil {
+		return nil, toStatus(err, "failed to list markets")
+	}
+
+	resp := &pb.ListMarketsResponse{TotalSize: int32(page.Total)}
+	markets := page.Markets
+	if len(markets) > pageSize {
+		markets = markets[:pageSize]
+		resp.NextPageToken = pageToken(markets[pageSize-1].ID)
+	}
+	for _, market := range markets {
+		resp.Markets = append(resp.Markets, toMarket(market))
+	}
+	return resp, nil
+}
+
+func (s *marketServer) GetMarket(ctx context.Context, req *pb.GetMarketRequest) (*pb.Market, error) {
+	market, err := s.markets.Get(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, "failed to fetch market")
+	}
+	return toMarket(market), nil
+}
+
+func (s *marketServer) WatchMarkets(req *pb.WatchMarketsRequest, stream pb.MarketService_WatchMarketsServer) error {
+	var marketIDs map[int]bool
+	if len(req.GetMarketIds()) > 0 {
+		marketIDs = make(map[int]bool, len(req.GetMarketIds()))
+		for _, id := range req.GetMarketIds() {
+			marketIDs[int(id)] = true
+		}
+	}
+
+	sub := s.events.Subscribe(0)
+	defer sub.Close()
+
+	for {
+		select {
+		case event := <-sub.C:
+			if event.Market == nil || (marketIDs != nil && !marketIDs[event.MarketID]) {
+				continue
+			}
+			if err := stream.Send(toEvent(event)); err != nil {
+				return err
+			}
+		case <-sub.Done():
+			return status.Error(codes.Unavailable, "event stream closed")
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+type tradingServer struct {
+	pb.UnimplementedTradingServiceServer
+	markets MarketService
+}
+
+func (s *tradingServer) PlaceBet(ctx context.Context, req *pb.PlaceBetRequest) (*pb.PlaceBetResponse, error) {
+	outcome, err := fromOutcome(req.GetOutcome())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.markets.PlaceBet(ctx, models.DefaultUser, models.BetRequest{
+		MarketID: int(req.GetMarketId()),
+		Outcome:  outcome,
+		Amount:   req.GetAmount(),
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to place bet")
+	}
+	return &pb.PlaceBetResponse{Market: toMarket(resp.Market), Balance: resp.Balance}, nil
+}
+
+func (s *tradingServer) ClaimWinnings(ctx context.Context, req *pb.ClaimWinningsRequest) (*pb.ClaimWinningsResponse, error) {
+	resp, err := s.markets.Claim(ctx, models.DefaultUser, int(req.GetMarketId()))
+	if err != nil {
+		return nil, toStatus(err, "failed to claim winnings")
+	}
+	return &pb.ClaimWinningsResponse{Payout: resp.Payout, Balance: resp.Balance}, nil
+}
+
+type adminServer struct {
+	pb.UnimplementedAdminServiceServer
+	markets MarketService
+}
+
+func (s *adminServer) ResolveMarket(ctx context.Context, req *pb.ResolveMarketRequest) (*pb.Market, error) {
+	outcome, err := fromOutcome(req.GetOutcome())
+	if err != nil {
+		return nil, err
+	}
+
+	market, err := s.markets.Resolve(ctx, int(req.GetMarketId()), outcome)
+	if err != nil {
+		return nil, toStatus(err, "failed to resolve market")
+	}
+	return toMarket(market), nil
+}
+
+func (s *adminServer) CancelMarket(ctx context.Context, req *pb.CancelMarketRequest) (*pb.Market, error) {
+	market, err := s.markets.Cancel(ctx, int(req.GetMarketId()))
+	if err != nil {
+		return nil, toStatus(err, "failed to cancel market")
+	}
+	return toMarket(market), nil
+}
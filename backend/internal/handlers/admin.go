@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// admin guards a route with the admin token, sent as "Authorization: Bearer
+// <token>". Without a token every request is let through.
+func (h *Handler) admin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.adminToken != "" && !validAdminToken(r.Header.Get("Authorization"), h.adminToken) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			respondError(w, http.StatusUnauthorized, CodeUnauthorized, "Admin token required")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// validAdminToken reports whether an Authorization header value carries token
+// as a bearer token
+func validAdminToken(authorization, token string) bool {
+	given, ok := strings.CutPrefix(authorization, "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdmin(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "no token set", wantStatus: http.StatusOK},
+		{name: "right token", token: "s3cret", authorization: "Bearer s3cret", wantStatus: http.StatusOK},
+		{name: "missing token", token: "s3cret", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", token: "s3cret", authorization: "Bearer guess", wantStatus: http.StatusUnauthorized},
+		{name: "not a bearer token", token: "s3cret", authorization: "s3cret", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{}
+			h.SetAdminToken(tt.token)
+			route := h.admin(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/markets/1/resolve", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			route(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// GetCategories returns every category; parentId links sub-categories to their parent
+func (h *Handler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.storage.GetCategories(r.Context())
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch categories")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, categories)
+}
+
+type categoryRequest struct {
+	Name     string `json:"name"`
+	ParentID *int   `json:"parentId"`
+}
+
+// CreateCategory adds a category, optionally below an existing parent (admin)
+func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req categoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, CodeInvalidBody, "Invalid request body")
+		return
+	}
+
+	category := &models.Category{Name: strings.TrimSpace(req.Name), ParentID: req.ParentID}
+	if err := h.validateCategory(r.Context(), category); err != nil {
+		respondFailure(w, err, "Failed to create category")
+		return
+	}
+
+	if err := h.storage.SaveCategory(r.Context(), category); err != nil {
+		respondFailure(w, err, "Failed to create category")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, category)
+}
+
+// UpdateCategory renames a category or moves it to another parent (admin)
+func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	category, ok := h.categoryFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req categoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, CodeInvalidBody, "Invalid request body")
+		return
+	}
+
+	category.Name = strings.TrimSpace(req.Name)
+	category.ParentID = req.ParentID
+	if err := h.validateCategory(r.Context(), category); err != nil {
+		respondFailure(w, err, "Failed to update category")
+		return
+	}
+
+	if err := h.storage.UpdateCategory(r.Context(), category); err != nil {
+		respondFailure(w, err, "Failed to update category")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, category)
+}
+
+// DeleteCategory removes a category that has no sub-categories and no markets (admin)
+func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	category, ok := h.categoryFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	categories, err := h.storage.GetCategories(r.Context())
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch categories")
+		return
+	}
+	for _, c := range categories {
+		if c.ParentID != nil && *c.ParentID == category.ID {
+			respondError(w, http.StatusConflict, CodeCategoryHasChildren, "Category has sub-categories")
+			return
+		}
+	}
+
+	page, err := h.storage.ListMarkets(r.Context(), models.MarketQuery{Category: category.Name, SortBy: models.SortEndingSoon, Limit: 1})
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch markets")
+		return
+	}
+	if page.Total > 0 {
+		respondError(w, http.StatusConflict, CodeCategoryHasMarkets, "Category has markets")
+		return
+	}
+
+	if err := h.storage.DeleteCategory(r.Context(), category.ID); err != nil {
+		respondFailure(w, err, "Failed to delete category")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetMarketTags replaces the tags of a market (admin)
+func (h *Handler) SetMarketTags(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid market ID")
+		return
+	}
+
+	var req struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, CodeInvalidBody, "Invalid request body")
+		return
+	}
+
+	market, err := h.markets.SetTags(r.Context(), id, req.Tags)
+	if err != nil {
+		respondFailure(w, err, "Failed to update tags")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, market)
+}
+
+// categoryFromPath loads the category named by the {id} route variable,
+// writing an error response and returning false if it cannot
+func (h *Handler) categoryFromPath(w http.ResponseWriter, r *http.Request) (*models.Category, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid category ID")
+		return nil, false
+	}
+
+	category, err := h.storage.GetCategory(r.Context(), id)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch category")
+		return nil, false
+	}
+	if category == nil {
+		respondError(w, http.StatusNotFound, CodeCategoryNotFound, "Category not found")
+		return nil, false
+	}
+	return category, true
+}
+
+// validateCategory checks the name is present and unique and that the parent
+// exists without creating a cycle, returning an *apiError when it is not.
+func (h *Handler) validateCategory(ctx context.Context, category *models.Category) error {
+	if category.Name == "" || len(category.Name) > 50 {
+		return &apiError{http.StatusBadRequest, CodeInvalidCategory, "Category name must be 1-50 characters"}
+	}
+
+	existing, err := h.storage.GetCategoryByName(ctx, category.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != category.ID {
+		return &apiError{http.StatusConflict, CodeCategoryExists, "Category already exists"}
+	}
+
+	// Walk up from the new parent; reaching the category itself means a cycle
+	for parentID := category.ParentID; parentID != nil; {
+		if category.ID != 0 && *parentID == category.ID {
+			return &apiError{http.StatusBadRequest, CodeInvalidCategory, "Category cannot be its own ancestor"}
+		}
+		parent, err := h.storage.GetCategory(ctx, *parentID)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			return &apiError{http.StatusBadRequest, CodeInvalidCategory, "Parent category not found"}
+		}
+		parentID = parent.ParentID
+	}
+
+	return nil
+}
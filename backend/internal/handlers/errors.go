@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/linera-prediction-market/backend/internal/linera"
+	"github.com/linera-prediction-market/backend/internal/market"
+	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/storage"
+	"github.com/linera-prediction-market/backend/internal/validation"
+)
+
+// Error codes returned in the "code" field of error responses. Rejected bets
+// use the bet codes defined in the validation package, and other refused
+// trades the codes of the market package's errors.
+const (
+	CodeInvalidBody         = "invalid_body"
+	CodeUnauthorized        = "unauthorized"
+	CodeInvalidParameter    = "invalid_parameter"
+	CodeValidationFailed    = "validation_failed"
+	CodeMarketNotFound      = "market_not_found"
+	CodeCategoryNotFound    = "category_not_found"
+	CodeInvalidCategory     = "invalid_category"
+	CodeCategoryExists      = "category_exists"
+	CodeCategoryHasChildren = "category_has_children"
+	CodeCategoryHasMarkets  = "category_has_markets"
+	CodeConflict            = "conflict"
+	CodeStorageUnavailable  = "storage_unavailable"
+	CodeLineraDisabled      = "linera_disabled"
+	CodeLineraUnavailable   = "linera_unavailable"
+	CodeLineraRejected      = "linera_rejected"
+	CodeInternal            = "internal_error"
+	CodeRequestCancelled    = "request_cancelled"
+)
+
+// RequestIDHeader carries the ID that ties a response to the server logs
+const RequestIDHeader = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID tags every request with an ID, reusing a well-formed X-Request-ID
+// sent by the client. The ID is echoed in the response header, where
+// respondError picks it up for the error body.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// apiError is a failure a helper reports with its response status and code
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+// errMarketNotFound is returned by helpers when the requested market does not exist
+var errMarketNotFound = &apiError{http.StatusNotFound, CodeMarketNotFound, "Market not found"}
+
+func respondError(w http.ResponseWriter, status int, code, message string) {
+	respondErrorDetails(w, status, code, message, nil)
+}
+
+func respondErrorDetails(w http.ResponseWriter, status int, code, message string, details interface{}) {
+	respondJSON(w, status, models.ErrorResponse{
+		Error:     message,
+		Code:      code,
+		Details:   details,
+		RequestID: w.Header().Get(RequestIDHeader),
+	})
+}
+
+// respondValidationError reports every invalid field of a request
+func respondValidationError(w http.ResponseWriter, errs validation.Errors) {
+	respondErrorDetails(w, http.StatusBadRequest, CodeValidationFailed, "Validation failed", errs)
+}
+
+// statusClientClosedRequest is the non-standard status nginx also logs for
+// requests the client abandoned before the response was ready
+const statusClientClosedRequest = 499
+
+// respondFailure reports err with the status and code it maps to. Errors
+// without a code of their own are logged and answered with message.
+func respondFailure(w http.ResponseWriter, err error, message string) {
+	var apiErr *apiError
+	var marketErr *market.Error
+	var betErr *validation.BetError
+	var fieldErrs validation.Errors
+
+	switch {
+	case errors.As(err, &apiErr):
+		respondError(w, apiErr.status, apiErr.code, apiErr.message)
+	case errors.As(err, &marketErr):
+		status := http.StatusBadRequest
+		if market.IsNotFound(marketErr) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, marketErr.Code, marketErr.Message)
+	case errors.As(err, &betErr):
+		respondError(w, http.StatusBadRequest, betErr.Code, betErr.Message)
+	case errors.As(err, &fieldErrs):
+		respondValidationError(w, fieldErrs)
+	case errors.Is(err, context.Canceled):
+		// The client went away; the response is only seen in access logs
+		log.Printf("🚫 [%s] %s: request cancelled", w.Header().Get(RequestIDHeader), message)
+		respondError(w, statusClientClosedRequest, CodeRequestCancelled, "Request cancelled")
+	default:
+		status, code := classifyError(err)
+		log.Printf("❌ [%s] %s: %v", w.Header().Get(RequestIDHeader), message, err)
+		respondError(w, status, code, message)
+	}
+}
+
+// classifyError maps storage and Linera errors to a response status and code
+func classifyError(err error) (int, string) {
+	switch {
+	case storage.IsUnavailable(err):
+		return http.StatusServiceUnavailable, CodeStorageUnavailable
+	case storage.IsConflict(err):
+		return http.StatusConflict, CodeConflict
+	case errors.Is(err, linera.ErrDisabled):
+		return http.StatusServiceUnavailable, CodeLineraDisabled
+	case errors.Is(err, linera.ErrUnavailable):
+		return http.StatusBadGateway, CodeLineraUnavailable
+	case errors.Is(err, linera.ErrRejected):
+		return http.StatusBadGateway, CodeLineraRejected
+	default:
+		return http.StatusInternalServerError, CodeInternal
+	}
+}
@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/linera-prediction-market/backend/internal/market"
+	"github.com/linera-prediction-market/backend/internal/validation"
+)
+
+// graphqlWSProtocol is the WebSocket subprotocol used for GraphQL subscriptions
+// (https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md)
+const graphqlWSProtocol = "graphql-transport-ws"
+
+// graphqlRequest is a GraphQL operation sent over HTTP or in a WebSocket subscribe message
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// graphqlError is a resolver error carrying the same code as the REST error response
+type graphqlError struct {
+	code    string
+	message string
+	details interface{}
+}
+
+func (e *graphqlError) Error() string {
+	return e.message
+}
+
+// Extensions is added to the error in the GraphQL response
+func (e *graphqlError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.code}
+	if e.details != nil {
+		extensions["details"] = e.details
+	}
+	return extensions
+}
+
+type requestIDKey struct{}
+
+// graphqlFailure converts err like respondFailure does. Errors without a
+// code of their own are logged and reported with message.
+func graphqlFailure(ctx context.Context, err error, message string) error {
+	var apiErr *apiError
+	var marketErr *market.Error
+	var betErr *validation.BetError
+	var fieldErrs validation.Errors
+
+	switch {
+	case errors.As(err, &apiErr):
+		return &graphqlError{code: apiErr.code, message: apiErr.message}
+	case errors.As(err, &marketErr):
+		return &graphqlError{code: marketErr.Code, message: marketErr.Message}
+	case errors.As(err, &betErr):
+		return &graphqlError{code: betErr.Code, message: betErr.Message}
+	case errors.As(err, &fieldErrs):
+		return &graphqlError{code: CodeValidationFailed, message: "Validation failed", details: fieldErrs}
+	case errors.Is(err, context.Canceled):
+		return &graphqlError{code: CodeRequestCancelled, message: "Request cancelled"}
+	default:
+		_, code := classifyError(err)
+		requestID, _ := ctx.Value(requestIDKey{}).(string)
+		log.Printf("❌ [%s] GraphQL: %s: %v", requestID, message, err)
+		return &graphqlError{code: code, message: message}
+	}
+}
+
+// operationType returns "query", "mutation" or "subscription" for the
+// operation a request will run, or "" if the document does not parse
+func operationType(query, operationName string) string {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return ""
+	}
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || (op.Name != nil && op.Name.Value == operationName) {
+			return op.Operation
+		}
+	}
+	return ""
+}
+
+// GraphQL serves queries and mutations over HTTP, and subscriptions over a
+// graphql-transport-ws WebSocket. GET requests take the query, variables and
+// operationName as query parameters and may not run mutations.
+func (h *Handler) GraphQL(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		h.graphqlWebSocket(w, r)
+		return
+	}
+
+	var req graphqlRequest
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid variables")
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, CodeInvalidBody, "Invalid request body")
+		return
+	}
+
+	if req.Query == "" {
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Missing query")
+		return
+	}
+
+	switch operationType(req.Query, req.OperationName) {
+	case ast.OperationTypeSubscription:
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Subscriptions require a WebSocket connection")
+		return
+	case ast.OperationTypeMutation:
+		if r.Method == http.MethodGet {
+			respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Mutations must be sent with POST")
+			return
+		}
+	}
+
+	ctx := context.WithValue(r.Context(), requestIDKey{}, w.Header().Get(RequestIDHeader))
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// graphqlWSMessage is a graphql-transport-ws message from the client
+type graphqlWSMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// graphqlWSReply is a graphql-transport-ws message from the server
+type graphqlWSReply struct {
+	ID      string      `json:"id,omitempty"`
+	Type    string      `json:"type"`
+	Payload interface{} `json:"payload,omitempty"`
+}
+
+// graphqlWSSession tracks the running operations of one WebSocket connection
+type graphqlWSSession struct {
+	conn *websocket.Conn
+
+	writeMu sync.Mutex
+
+	mu  sync.Mutex
+	ops map[string]context.CancelFunc
+}
+
+func (s *graphqlWSSession) send(reply graphqlWSReply) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	return writeWS(s.conn, reply)
+}
+
+// start registers an operation, returning false if the ID is already in use
+func (s *graphqlWSSession) start(id string, cancel context.CancelFunc) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.ops[id]; ok {
+		return false
+	}
+	s.ops[id] = cancel
+	return true
+}
+
+// stop cancels an operation, reporting whether it was still running
+func (s *graphqlWSSession) stop(id string) bool {
+	s.mu.Lock()
+	cancel, ok := s.ops[id]
+	delete(s.ops, id)
+	s.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// closeWS ends the connection with a graphql-transport-ws close code
+func closeWS(conn *websocket.Conn, code int, reason string) {
+	msg := websocket.FormatCloseMessage(code, reason)
+	conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteWait))
+}
+
+// graphqlWebSocket runs GraphQL operations over the graphql-transport-ws protocol.
+// Subscriptions stream a next message per event until the client completes them.
+func (h *Handler) graphqlWebSocket(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     h.checkOrigin,
+		Subprotocols:    []string{graphqlWSProtocol},
+	}
+
+	requestID := w.Header().Get(RequestIDHeader)
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("⚠️  GraphQL WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	if conn.Subprotocol() != graphqlWSProtocol {
+		closeWS(conn, 4406, "Subprotocol not acceptable")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), requestIDKey{}, requestID))
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	session := &graphqlWSSession{conn: conn, ops: make(map[string]context.CancelFunc)}
+
+	// Keep-alive pings; WriteControl may run alongside session writes
+	go func() {
+		ticker := time.NewTicker(wsPingPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	conn.SetReadLimit(wsMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	acknowledged := false
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Printf("🔌 GraphQL WebSocket client disconnected: %v", err)
+			}
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+
+		var msg graphqlWSMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			closeWS(conn, 4400, "Invalid message")
+			return
+		}
+
+		switch msg.Type {
+		case "connection_init":
+			if acknowledged {
+				closeWS(conn, 4429, "Too many initialisation requests")
+				return
+			}
+			acknowledged = true
+			if err := session.send(graphqlWSReply{Type: "connection_ack"}); err != nil {
+				return
+			}
+
+		case "ping":
+			if err := session.send(graphqlWSReply{Type: "pong"}); err != nil {
+				return
+			}
+
+		case "pong":
+			// Answers a ping we never send; nothing to do
+
+		case "subscribe":
+			if !acknowledged {
+				closeWS(conn, 4401, "Unauthorized")
+				return
+			}
+			var req graphqlRequest
+			if msg.ID == "" || json.Unmarshal(msg.Payload, &req) != nil {
+				closeWS(conn, 4400, "Invalid subscribe message")
+				return
+			}
+
+			opCtx, opCancel := context.WithCancel(ctx)
+			if !session.start(msg.ID, opCancel) {
+				opCancel()
+				closeWS(conn, 4409, "Subscriber for "+msg.ID+" already exists")
+				return
+			}
+
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				h.runGraphQLOperation(opCtx, session, id, req)
+			}(msg.ID)
+
+		case "complete":
+			session.stop(msg.ID)
+
+		default:
+			closeWS(conn, 4400, "Invalid message type")
+			return
+		}
+	}
+}
+
+// runGraphQLOperation executes one operation of a WebSocket session and
+// sends its results. A client that completes the operation first gets no
+// further messages for it.
+func (h *Handler) runGraphQLOperation(ctx context.Context, session *graphqlWSSession, id string, req graphqlRequest) {
+	params := graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	}
+
+	var results chan *graphql.Result
+	if operationType(req.Query, req.OperationName) == ast.OperationTypeSubscription {
+		results = graphql.Subscribe(params)
+	} else {
+		results = make(chan *graphql.Result, 1)
+		results <- graphql.Do(params)
+		close(results)
+	}
+
+	// Drain every result so the executor can finish, even after a send fails
+	first, failed := true, false
+	for result := range results {
+		if failed || ctx.Err() != nil {
+			continue
+		}
+
+		reply := graphqlWSReply{ID: id, Type: "next", Payload: result}
+		if first && result.Data == nil && len(result.Errors) > 0 {
+			// The operation was rejected before it ran
+			reply = graphqlWSReply{ID: id, Type: "error", Payload: result.Errors}
+			failed = true
+		}
+		first = false
+
+		if err := session.send(reply); err != nil {
+			failed = true
+		}
+	}
+
+	cancelled := ctx.Err() != nil
+	if session.stop(id) && !failed && !cancelled {
+		session.send(graphqlWSReply{ID: id, Type: "complete"})
+	}
+}
@@ -0,0 +1,533 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// newGraphQLSchema builds the schema served at /api/graphql. Every resolver
+// goes through the same storage, validation and event bus as the REST handlers.
+func (h *Handler) newGraphQLSchema() graphql.Schema {
+	outcomeEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Outcome",
+		Values: graphql.EnumValueConfigMap{
+			"Yes": &graphql.EnumValueConfig{Value: models.OutcomeYes},
+			"No":  &graphql.EnumValueConfig{Value: models.OutcomeNo},
+		},
+	})
+
+	statusEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "MarketStatus",
+		Values: graphql.EnumValueConfigMap{
+			"Active":    &graphql.EnumValueConfig{Value: models.StatusActive},
+			"Locked":    &graphql.EnumValueConfig{Value: models.StatusLocked},
+			"Resolved":  &graphql.EnumValueConfig{Value: models.StatusResolved},
+			"Cancelled": &graphql.EnumValueConfig{Value: models.StatusCancelled},
+		},
+	})
+
+	oddsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Odds",
+		Description: "Implied probability of each outcome derived from the pools",
+		Fields: graphql.Fields{
+			"yes": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"no":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	marketType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Market",
+		Fields: graphql.Fields{
+			"id":               &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"question":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"category":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"status":           &graphql.Field{Type: graphql.NewNonNull(statusEnum)},
+			"endTime":          &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"yesPool":          &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"noPool":           &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"totalYesShares":   &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"totalNoShares":    &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"winningOutcome":   &graphql.Field{Type: outcomeEnum},
+			"resolutionSource": &graphql.Field{Type: graphql.String},
+			"tags": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if tags := p.Source.(*models.Market).Tags; tags != nil {
+						return tags, nil
+					}
+					return []string{}, nil
+				},
+			},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"odds": &graphql.Field{
+				Type: graphql.NewNonNull(oddsType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return models.CalculateOdds(p.Source.(*models.Market)), nil
+				},
+			},
+		},
+	})
+
+	marketPageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "MarketPage",
+		Fields: graphql.Fields{
+			"markets":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(marketType)))},
+			"total":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"nextCursor": &graphql.Field{Type: graphql.String, Description: "Pass as cursor to fetch the next page; null on the last page"},
+		},
+	})
+
+	positionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Position",
+		Fields: graphql.Fields{
+			"marketId":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"yesShares": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"noShares":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"yesAmount": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"noAmount":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"claimed":   &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"market": &graphql.Field{
+				Type: marketType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					market, err := h.storage.GetMarket(p.Context, p.Source.(*models.UserPosition).MarketID)
+					if err != nil {
+						return nil, graphqlFailure(p.Context, err, "Failed to fetch market")
+					}
+					return market, nil
+				},
+			},
+		},
+	})
+
+	valuationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PositionValuation",
+		Fields: graphql.Fields{
+			"market":        &graphql.Field{Type: marketType},
+			"position":      &graphql.Field{Type: graphql.NewNonNull(positionType)},
+			"costBasis":     &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"markValue":     &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"unrealizedPnl": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"realizedPnl":   &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"claimable":     &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	totalsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PortfolioTotals",
+		Fields: graphql.Fields{
+			"costBasis":     &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"markValue":     &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"unrealizedPnl": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"realizedPnl":   &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"claimable":     &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"balance":       &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"equity":        &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	portfolioType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Portfolio",
+		Fields: graphql.Fields{
+			"positions": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(valuationType)))},
+			"totals":    &graphql.Field{Type: graphql.NewNonNull(totalsType)},
+		},
+	})
+
+	tradeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Trade",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"user":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"marketId":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"outcome":   &graphql.Field{Type: graphql.NewNonNull(outcomeEnum)},
+			"amount":    &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"shares":    &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"price":     &graphql.Field{Type: graphql.NewNonNull(graphql.Float), Description: "Implied probability of the outcome before the bet"},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	tradePageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TradePage",
+		Fields: graphql.Fields{
+			"trades":     &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tradeType)))},
+			"nextCursor": &graphql.Field{Type: graphql.String, Description: "Pass as cursor to fetch the next page; null on the last page"},
+		},
+	})
+
+	candleType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Candle",
+		Description: "OHLC bucket of the Yes probability with traded volume",
+		Fields: graphql.Fields{
+			"time":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"open":   &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"high":   &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"low":    &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"close":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"volume": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	historyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PriceHistory",
+		Fields: graphql.Fields{
+			"marketId": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"interval": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"from":     &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"to":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"candles":  &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(candleType)))},
+		},
+	})
+
+	betResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BetResult",
+		Fields: graphql.Fields{
+			"success": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"market":  &graphql.Field{Type: graphql.NewNonNull(marketType)},
+			"balance": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	claimResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ClaimResult",
+		Fields: graphql.Fields{
+			"success": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"payout":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"balance": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	eventType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MarketEvent",
+		Description: "A market update, as published on the WebSocket and SSE feeds",
+		Fields: graphql.Fields{
+			"seq":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"type":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "market.created, bet.placed or market.status"},
+			"marketId":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"market":    &graphql.Field{Type: graphql.NewNonNull(marketType)},
+			"odds":      &graphql.Field{Type: graphql.NewNonNull(oddsType)},
+			"timestamp": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	createMarketInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateMarketInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"question":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"category":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"endTime":          &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String), Description: "RFC3339 timestamp"},
+			"resolutionSource": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"tags":             &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"markets": &graphql.Field{
+				Type:        graphql.NewNonNull(marketPageType),
+				Description: "Markets filtered and sorted like GET /api/markets",
+				Args: graphql.FieldConfigArgument{
+					"status":   &graphql.ArgumentConfig{Type: statusEnum},
+					"category": &graphql.ArgumentConfig{Type: graphql.String, Description: "Also matches sub-categories"},
+					"tag":      &graphql.ArgumentConfig{Type: graphql.String},
+					"sortBy":   &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: models.SortEndingSoon, Description: "ending-soon, newest, popular or alphabetical"},
+					"limit":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20},
+					"cursor":   &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: h.resolveMarkets,
+			},
+			"market": &graphql.Field{
+				Type:        marketType,
+				Description: "A single market, or null if it does not exist",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					market, err := h.storage.GetMarket(p.Context, p.Args["id"].(int))
+					if err != nil {
+						return nil, graphqlFailure(p.Context, err, "Failed to fetch market")
+					}
+					return market, nil
+				},
+			},
+			"positions": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(positionType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					positions, err := h.storage.GetPositions(p.Context)
+					if err != nil {
+						return nil, graphqlFailure(p.Context, err, "Failed to fetch positions")
+					}
+					return positions, nil
+				},
+			},
+			"balance": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Float),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					balance, err := h.storage.GetBalance(p.Context)
+					if err != nil {
+						return nil, graphqlFailure(p.Context, err, "Failed to fetch balance")
+					}
+					return balance, nil
+				},
+			},
+			"portfolio": &graphql.Field{
+				Type: graphql.NewNonNull(portfolioType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					result, err := h.portfolio(p.Context)
+					if err != nil {
+						return nil, graphqlFailure(p.Context, err, "Failed to fetch portfolio")
+					}
+					return result, nil
+				},
+			},
+			"trades": &graphql.Field{
+				Type:        graphql.NewNonNull(tradePageType),
+				Description: "Trades, newest first, optionally for one market or user",
+				Args: graphql.FieldConfigArgument{
+					"marketId": &graphql.ArgumentConfig{Type: graphql.Int},
+					"user":     &graphql.ArgumentConfig{Type: graphql.String},
+					"limit":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 50},
+					"cursor":   &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: h.resolveTrades,
+			},
+			"history": &graphql.Field{
+				Type:        graphql.NewNonNull(historyType),
+				Description: "OHLC candles of a market's Yes probability",
+				Args: graphql.FieldConfigArgument{
+					"marketId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"interval": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "1h", Description: "1m, 5m, 15m, 1h, 4h or 1d"},
+					"from":     &graphql.ArgumentConfig{Type: graphql.DateTime, Description: "Defaults to the market's creation"},
+					"to":       &graphql.ArgumentConfig{Type: graphql.DateTime, Description: "Defaults to now"},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					from, _ := p.Args["from"].(time.Time)
+					to, _ := p.Args["to"].(time.Time)
+					result, err := h.marketHistory(p.Context, p.Args["marketId"].(int), p.Args["interval"].(string), from, to)
+					if err != nil {
+						return nil, graphqlFailure(p.Context, err, "Failed to fetch price history")
+					}
+					return result, nil
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"placeBet": &graphql.Field{
+				Type: graphql.NewNonNull(betResultType),
+				Args: graphql.FieldConfigArgument{
+					"marketId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"outcome":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(outcomeEnum)},
+					"amount":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Float)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					resp, err := h.markets.PlaceBet(p.Context, models.DefaultUser, models.BetRequest{
+						MarketID: p.Args["marketId"].(int),
+						Outcome:  p.Args["outcome"].(models.Outcome),
+						Amount:   p.Args["amount"].(float64),
+					})
+					if err != nil {
+						return nil, graphqlFailure(p.Context, err, "Failed to place bet")
+					}
+					return resp, nil
+				},
+			},
+			"claimWinnings": &graphql.Field{
+				Type: graphql.NewNonNull(claimResultType),
+				Args: graphql.FieldConfigArgument{
+					"marketId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					resp, err := h.markets.Claim(p.Context, models.DefaultUser, p.Args["marketId"].(int))
+					if err != nil {
+						return nil, graphqlFailure(p.Context, err, "Failed to claim winnings")
+					}
+					return resp, nil
+				},
+			},
+			"createMarket": &graphql.Field{
+				Type: graphql.NewNonNull(marketType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createMarketInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					input := p.Args["input"].(map[string]interface{})
+					req := createMarketRequest{
+						Question: input["question"].(string),
+						Category: input["category"].(string),
+						EndTime:  input["endTime"].(string),
+					}
+					req.ResolutionSource, _ = input["resolutionSource"].(string)
+					if tags, ok := input["tags"].([]interface{}); ok {
+						for _, tag := range tags {
+							req.Tags = append(req.Tags, tag.(string))
+						}
+					}
+
+					market, err := h.createMarket(p.Context, req)
+					if err != nil {
+						return nil, graphqlFailure(p.Context, err, "Failed to create market")
+					}
+					return market, nil
+				},
+			},
+		},
+	})
+
+	subscription := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"marketUpdated": &graphql.Field{
+				Type:        graphql.NewNonNull(eventType),
+				Description: "Market creations, bets and status changes, optionally limited to some markets",
+				Args: graphql.FieldConfigArgument{
+					"marketIds": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.Int))},
+				},
+				Subscribe: h.subscribeMarketUpdates,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:        query,
+		Mutation:     mutation,
+		Subscription: subscription,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("invalid GraphQL schema: %v", err))
+	}
+	return schema
+}
+
+// resolveMarkets lists a page of markets like GetMarkets
+func (h *Handler) resolveMarkets(p graphql.ResolveParams) (interface{}, error) {
+	limit := p.Args["limit"].(int)
+	if limit <= 0 || limit > 100 {
+		return nil, graphqlFailure(p.Context, &apiError{http.StatusBadRequest, CodeInvalidParameter, "Invalid limit (1-100)"}, "")
+	}
+
+	sortBy, _ := p.Args["sortBy"].(string)
+	if !validSorts[sortBy] {
+		sortBy = models.SortEndingSoon
+	}
+
+	query := models.MarketQuery{
+		SortBy: sortBy,
+		Limit:  limit + 1, // one extra row tells us whether another page exists
+	}
+	if status, ok := p.Args["status"].(models.MarketStatus); ok {
+		query.Status = string(status)
+	}
+	query.Category, _ = p.Args["category"].(string)
+	query.Tag, _ = p.Args["tag"].(string)
+
+	if cursor, ok := p.Args["cursor"].(string); ok {
+		after, err := strconv.Atoi(cursor)
+		if err != nil || after <= 0 {
+			return nil, graphqlFailure(p.Context, &apiError{http.StatusBadRequest, CodeInvalidParameter, "Invalid cursor"}, "")
+		}
+		query.After = after
+	}
+
+	result, err := h.markets.List(p.Context, query)
+	if err != nil {
+		return nil, graphqlFailure(p.Context, err, "Failed to fetch markets")
+	}
+
+	markets := result.Markets
+	var nextCursor *string
+	if len(markets) > limit {
+		markets = markets[:limit]
+		cursor := strconv.Itoa(markets[limit-1].ID)
+		nextCursor = &cursor
+	}
+	if markets == nil {
+		markets = []*models.Market{}
+	}
+
+	return map[string]interface{}{
+		"markets":    markets,
+		"total":      result.Total,
+		"nextCursor": nextCursor,
+	}, nil
+}
+
+// resolveTrades returns a page of trades with the same limits as GET /api/trades
+func (h *Handler) resolveTrades(p graphql.ResolveParams) (interface{}, error) {
+	query := models.TradeQuery{Limit: p.Args["limit"].(int)}
+	if query.Limit <= 0 || query.Limit > 200 {
+		return nil, graphqlFailure(p.Context, &apiError{http.StatusBadRequest, CodeInvalidParameter, "Invalid limit (1-200)"}, "")
+	}
+	query.MarketID, _ = p.Args["marketId"].(int)
+	query.User, _ = p.Args["user"].(string)
+
+	if cursor, ok := p.Args["cursor"].(string); ok {
+		before, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || before <= 0 {
+			return nil, graphqlFailure(p.Context, &apiError{http.StatusBadRequest, CodeInvalidParameter, "Invalid cursor"}, "")
+		}
+		query.Before = before
+	}
+
+	trades, nextCursor, err := h.tradePage(p.Context, query)
+	if err != nil {
+		return nil, graphqlFailure(p.Context, err, "Failed to fetch trades")
+	}
+
+	return map[string]interface{}{
+		"trades":     trades,
+		"nextCursor": nextCursor,
+	}, nil
+}
+
+// subscribeMarketUpdates feeds market events from the event bus into a
+// subscription until its context is cancelled
+func (h *Handler) subscribeMarketUpdates(p graphql.ResolveParams) (interface{}, error) {
+	var marketIDs map[int]bool
+	if ids, ok := p.Args["marketIds"].([]interface{}); ok {
+		marketIDs = make(map[int]bool, len(ids))
+		for _, id := range ids {
+			marketIDs[id.(int)] = true
+		}
+	}
+
+	sub := h.events.Subscribe(0)
+	updates := make(chan interface{})
+
+	go func() {
+		defer close(updates)
+		defer sub.Close()
+
+		for {
+			select {
+			case event := <-sub.C:
+				if event.Market == nil || (marketIDs != nil && !marketIDs[event.MarketID]) {
+					continue
+				}
+				select {
+				case updates <- event:
+				case <-p.Context.Done():
+					return
+				}
+			case <-sub.Done():
+				return
+			case <-p.Context.Done():
+				return
+			}
+		}
+	}()
+
+	return updates, nil
+}
@@ -1,49 +1,83 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
-	"log"
-	"math"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/graphql-go/graphql"
+	"github.com/linera-prediction-market/backend/internal/events"
+	"github.com/linera-prediction-market/backend/internal/market"
 	"github.com/linera-prediction-market/backend/internal/models"
-	"github.com/linera-prediction-market/backend/internal/storage"
+	"github.com/linera-prediction-market/backend/internal/validation"
 )
 
 // StorageInterface defines the methods required for storage operations
 type StorageInterface interface {
-	GetMarkets() ([]*models.Market, error)
-	GetMarket(id int) (*models.Market, error)
-	SaveMarket(market *models.Market) error
-	UpdateMarket(market *models.Market) error
-	GetPositions() ([]*models.UserPosition, error)
-	GetPosition(marketID int) (*models.UserPosition, error)
-	SavePosition(position *models.UserPosition) error
-	GetBalance() (float64, error)
-	UpdateBalance(amount float64) error
+	ListMarkets(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error)
+	SearchMarkets(ctx context.Context, q models.MarketSearch) ([]*models.Market, error)
+	GetMarket(ctx context.Context, id int) (*models.Market, error)
+	GetMarketsByID(ctx context.Context, ids []int) ([]*models.Market, error)
+	GetCategories(ctx context.Context) ([]*models.Category, error)
+	GetCategory(ctx context.Context, id int) (*models.Category, error)
+	GetCategoryByName(ctx context.Context, name string) (*models.Category, error)
+	SaveCategory(ctx context.Context, category *models.Category) error
+	UpdateCategory(ctx context.Context, category *models.Category) error
+	DeleteCategory(ctx context.Context, id int) error
+	GetPositions(ctx context.Context) ([]*models.UserPosition, error)
+	GetBalance(ctx context.Context) (float64, error)
+	GetPriceHistory(ctx context.Context, marketID int, from, to time.Time) ([]*models.PriceSnapshot, error)
+	GetTrades(ctx context.Context, query models.TradeQuery) ([]*models.Trade, error)
+	GetLeaderboard(ctx context.Context, period, metric string, limit int) ([]*models.LeaderboardEntry, error)
 }
 
-// LineraClient defines the interface for Linera contract operations
-type LineraClient interface {
-	IsEnabled() bool
-	PlaceBet(marketID int, outcome string, amount int) error
-	ResolveMarket(marketID int, outcome string) error
-	CreateMarket(question string, category string, endTime time.Time) error
+// MarketService applies the market rules shared with the other APIs
+type MarketService interface {
+	List(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error)
+	PlaceBet(ctx context.Context, user string, req models.BetRequest) (*models.BetResponse, error)
+	Claim(ctx context.Context, user string, marketID int) (*models.ClaimResponse, error)
+	Create(ctx context.Context, req market.CreateRequest) (*models.Market, error)
+	Resolve(ctx context.Context, id int, outcome models.Outcome) (*models.Market, error)
+	SetTags(ctx context.Context, id int, tags []string) (*models.Market, error)
+}
+
+// EventBus defines the event bus used to publish and stream market updates
+type EventBus interface {
+	Publish(event models.Event)
+	Subscribe(buffer int) *events.Subscription
+	EventsSince(ctx context.Context, afterSeq int64, limit int) ([]models.Event, error)
 }
 
 type Handler struct {
-	storage      StorageInterface
-	lineraClient LineraClient
+	storage        StorageInterface
+	markets        MarketService
+	events         EventBus
+	allowedOrigins []string
+	adminToken     string
+	schema         graphql.Schema
 }
 
-func New(s StorageInterface, l LineraClient) *Handler {
-	return &Handler{
-		storage:      s,
-		lineraClient: l,
+func New(s StorageInterface, m MarketService, e EventBus) *Handler {
+	h := &Handler{
+		storage: s,
+		markets: m,
+		events:  e,
 	}
+	h.schema = h.newGraphQLSchema()
+	return h
+}
+
+// AllowOrigins sets the origins allowed to open WebSocket connections
+func (h *Handler) AllowOrigins(origins ...string) {
+	h.allowedOrigins = origins
+}
+
+// SetAdminToken sets the bearer token admin routes require; empty leaves them open
+func (h *Handler) SetAdminToken(token string) {
+	h.adminToken = token
 }
 
 func (h *Handler) GetMarkets(w http.ResponseWriter, r *http.Request) {
@@ -63,143 +97,82 @@ func (h *Handler) GetMarkets(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	
-	markets, err := h.storage.GetMarkets()
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch markets")
-		return
-	}
-	
-	// Update market statuses based on end time
-	now := time.Now()
-	for _, market := range markets {
-		if market.Status == models.StatusActive && market.EndTime.Before(now) {
-			market.Status = models.StatusLocked
-			// Update in storage
-			h.storage.UpdateMarket(market)
-		}
-	}
-	
-	// Filter by status if provided
-	statusFilter := r.URL.Query().Get("status")
-	if statusFilter != "" {
-		var filteredMarkets []*models.Market
-		for _, market := range markets {
-			if string(market.Status) == statusFilter {
-				filteredMarkets = append(filteredMarkets, market)
-			}
-		}
-		markets = filteredMarkets
-	}
-	
-	// Filter by category if provided
-	categoryFilter := r.URL.Query().Get("category")
-	if categoryFilter != "" {
-		var filteredMarkets []*models.Market
-		for _, market := range markets {
-			if string(market.Category) == categoryFilter {
-				filteredMarkets = append(filteredMarkets, market)
-			}
-		}
-		markets = filteredMarkets
+	// Unknown sort orders fall back to the default
+	sortBy := r.URL.Query().Get("sortBy")
+	if !validSorts[sortBy] {
+		sortBy = models.SortEndingSoon
 	}
 	
-	// Sort markets
-	sortBy := r.URL.Query().Get("sortBy")
-	if sortBy == "" {
-		sortBy = "ending-soon" // Default sort
+	query := models.MarketQuery{
+		Status:   r.URL.Query().Get("status"),
+		Category: r.URL.Query().Get("category"),
+		Tag:      r.URL.Query().Get("tag"),
+		SortBy:   sortBy,
+		Offset:   (page - 1) * limit,
+		Limit:    limit + 1, // one extra row tells us whether another page exists
 	}
 	
-	switch sortBy {
-	case "newest":
-		// Sort by created_at DESC
-		for i := 0; i < len(markets)-1; i++ {
-			for j := i + 1; j < len(markets); j++ {
-				if markets[i].CreatedAt.Before(markets[j].CreatedAt) {
-					markets[i], markets[j] = markets[j], markets[i]
-				}
-			}
-		}
-	case "ending-soon":
-		// Sort by end_time ASC
-		for i := 0; i < len(markets)-1; i++ {
-			for j := i + 1; j < len(markets); j++ {
-				if markets[i].EndTime.After(markets[j].EndTime) {
-					markets[i], markets[j] = markets[j], markets[i]
-				}
-			}
-		}
-	case "popular":
-		// Sort by total volume DESC
-		for i := 0; i < len(markets)-1; i++ {
-			for j := i + 1; j < len(markets); j++ {
-				volumeI := markets[i].YesPool + markets[i].NoPool
-				volumeJ := markets[j].YesPool + markets[j].NoPool
-				if volumeI < volumeJ {
-					markets[i], markets[j] = markets[j], markets[i]
-				}
-			}
-		}
-	case "alphabetical":
-		// Sort by question ASC
-		for i := 0; i < len(markets)-1; i++ {
-			for j := i + 1; j < len(markets); j++ {
-				if markets[i].Question > markets[j].Question {
-					markets[i], markets[j] = markets[j], markets[i]
-				}
-			}
+	// ?cursor= (the ID of the last market on the previous page) takes precedence over ?page=
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		after, err := strconv.Atoi(cursor)
+		if err != nil || after <= 0 {
+			respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid cursor")
+			return
 		}
+		query.After = after
 	}
 	
-	// Calculate pagination
-	total := len(markets)
-	start := (page - 1) * limit
-	end := start + limit
-	
-	if start >= total {
-		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"markets": []models.Market{},
-			"pagination": map[string]interface{}{
-				"page":       page,
-				"limit":      limit,
-				"total":      total,
-				"totalPages": (total + limit - 1) / limit,
-			},
-		})
+	// Markets that have passed their end time are locked before listing
+	result, err := h.markets.List(r.Context(), query)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch markets")
 		return
 	}
 	
-	if end > total {
-		end = total
+	markets := result.Markets
+	var nextCursor *string
+	if len(markets) > limit {
+		markets = markets[:limit]
+		cursor := strconv.Itoa(markets[limit-1].ID)
+		nextCursor = &cursor
 	}
 	
-	paginatedMarkets := markets[start:end]
-	
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"markets": paginatedMarkets,
+		"markets":    markets,
+		"nextCursor": nextCursor,
 		"pagination": map[string]interface{}{
 			"page":       page,
 			"limit":      limit,
-			"total":      total,
-			"totalPages": (total + limit - 1) / limit,
+			"total":      result.Total,
+			"totalPages": (result.Total + limit - 1) / limit,
 		},
 	})
 }
 
+// validSorts is the set of sortBy values accepted by GetMarkets
+var validSorts = func() map[string]bool {
+	sorts := make(map[string]bool)
+	for _, s := range models.MarketSorts {
+		sorts[s] = true
+	}
+	return sorts
+}()
+
 func (h *Handler) GetMarket(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid market ID")
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid market ID")
 		return
 	}
 
-	market, err := h.storage.GetMarket(id)
+	market, err := h.storage.GetMarket(r.Context(), id)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch market")
+		respondFailure(w, err, "Failed to fetch market")
 		return
 	}
 	if market == nil {
-		respondError(w, http.StatusNotFound, "Market not found")
+		respondError(w, http.StatusNotFound, CodeMarketNotFound, "Market not found")
 		return
 	}
 
@@ -207,18 +180,18 @@ func (h *Handler) GetMarket(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) GetPositions(w http.ResponseWriter, r *http.Request) {
-	positions, err := h.storage.GetPositions()
+	positions, err := h.storage.GetPositions(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch positions")
+		respondFailure(w, err, "Failed to fetch positions")
 		return
 	}
 	respondJSON(w, http.StatusOK, positions)
 }
 
 func (h *Handler) GetBalance(w http.ResponseWriter, r *http.Request) {
-	balance, err := h.storage.GetBalance()
+	balance, err := h.storage.GetBalance(r.Context())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch balance")
+		respondFailure(w, err, "Failed to fetch balance")
 		return
 	}
 	respondJSON(w, http.StatusOK, map[string]float64{"balance": balance})
@@ -227,157 +200,39 @@ func (h *Handler) GetBalance(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) PlaceBet(w http.ResponseWriter, r *http.Request) {
 	var req models.BetRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
-		return
-	}
-
-	market, err := h.storage.GetMarket(req.MarketID)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch market")
-		return
-	}
-	if market == nil {
-		respondError(w, http.StatusNotFound, "Market not found")
+		respondError(w, http.StatusBadRequest, CodeInvalidBody, "Invalid request body")
 		return
 	}
 
-	if market.Status != models.StatusActive {
-		respondError(w, http.StatusBadRequest, "Market is not active")
-		return
-	}
-
-	balance, err := h.storage.GetBalance()
+	resp, err := h.markets.PlaceBet(r.Context(), models.DefaultUser, req)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch balance")
-		return
-	}
-	if req.Amount > balance {
-		respondError(w, http.StatusBadRequest, "Insufficient balance")
+		respondFailure(w, err, "Failed to place bet")
 		return
 	}
 
-	// Update market pools and shares
-	if req.Outcome == models.OutcomeYes {
-		shares := storage.CalculateShares(market.YesPool, market.TotalYesShares, req.Amount)
-		market.YesPool += req.Amount
-		market.TotalYesShares += shares
-
-		// Update or create position
-		position, err := h.storage.GetPosition(req.MarketID)
-		if err != nil {
-			respondError(w, http.StatusInternalServerError, "Failed to fetch position")
-			return
-		}
-		if position == nil {
-			position = &models.UserPosition{
-				MarketID: req.MarketID,
-			}
-		}
-		position.YesShares += shares
-		position.YesAmount += req.Amount
-		
-		if err := h.storage.SavePosition(position); err != nil {
-			respondError(w, http.StatusInternalServerError, "Failed to save position")
-			return
-		}
-	} else {
-		shares := storage.CalculateShares(market.NoPool, market.TotalNoShares, req.Amount)
-		market.NoPool += req.Amount
-		market.TotalNoShares += shares
-
-		position, err := h.storage.GetPosition(req.MarketID)
-		if err != nil {
-			respondError(w, http.StatusInternalServerError, "Failed to fetch position")
-			return
-		}
-		if position == nil {
-			position = &models.UserPosition{
-				MarketID: req.MarketID,
-			}
-		}
-		position.NoShares += shares
-		position.NoAmount += req.Amount
-		
-		if err := h.storage.SavePosition(position); err != nil {
-			respondError(w, http.StatusInternalServerError, "Failed to save position")
-			return
-		}
-	}
-
-	if err := h.storage.UpdateMarket(market); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update market")
-		return
-	}
-
-	if err := h.storage.UpdateBalance(-req.Amount); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update balance")
-		return
-	}
-
-	// Sync to Linera contract (async, best-effort)
-	if h.lineraClient.IsEnabled() {
-		go func() {
-			outcomeStr := string(req.Outcome)
-			if err := h.lineraClient.PlaceBet(req.MarketID, outcomeStr, int(req.Amount)); err != nil {
-				log.Printf("⚠️  Failed to sync bet to Linera: %v", err)
-			} else {
-				log.Printf("✅ Synced bet to Linera: market #%d, %s, %.0f tokens", req.MarketID, outcomeStr, req.Amount)
-			}
-		}()
-	}
-
-	balance, _ = h.storage.GetBalance()
-	respondJSON(w, http.StatusOK, models.BetResponse{
-		Success: true,
-		Market:  market,
-		Balance: balance,
-	})
+	respondJSON(w, http.StatusOK, resp)
 }
 
 func (h *Handler) ResolveMarket(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid market ID")
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid market ID")
 		return
 	}
 
 	var req models.ResolveRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+		respondError(w, http.StatusBadRequest, CodeInvalidBody, "Invalid request body")
 		return
 	}
 
-	market, err := h.storage.GetMarket(id)
+	market, err := h.markets.Resolve(r.Context(), id, req.Outcome)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch market")
-		return
-	}
-	if market == nil {
-		respondError(w, http.StatusNotFound, "Market not found")
+		respondFailure(w, err, "Failed to resolve market")
 		return
 	}
 
-	market.Status = models.StatusResolved
-	market.WinningOutcome = &req.Outcome
-
-	if err := h.storage.UpdateMarket(market); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update market")
-		return
-	}
-
-	// Sync to Linera contract (async, best-effort)
-	if h.lineraClient.IsEnabled() {
-		go func() {
-			outcomeStr := string(req.Outcome)
-			if err := h.lineraClient.ResolveMarket(id, outcomeStr); err != nil {
-				log.Printf("⚠️  Failed to sync market resolution to Linera: %v", err)
-			} else {
-				log.Printf("✅ Synced market resolution to Linera: market #%d → %s", id, outcomeStr)
-			}
-		}()
-	}
-
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"success": true,
 		"market":  market,
@@ -388,72 +243,17 @@ func (h *Handler) ClaimWinnings(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	marketID, err := strconv.Atoi(vars["marketId"])
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid market ID")
-		return
-	}
-
-	market, err := h.storage.GetMarket(marketID)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch market")
-		return
-	}
-	if market == nil {
-		respondError(w, http.StatusNotFound, "Market not found")
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid market ID")
 		return
 	}
 
-	position, err := h.storage.GetPosition(marketID)
+	resp, err := h.markets.Claim(r.Context(), models.DefaultUser, marketID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch position")
-		return
-	}
-	if position == nil {
-		respondError(w, http.StatusNotFound, "Position not found")
+		respondFailure(w, err, "Failed to claim winnings")
 		return
 	}
 
-	if market.Status != models.StatusResolved {
-		respondError(w, http.StatusBadRequest, "Market not resolved yet")
-		return
-	}
-
-	if position.Claimed {
-		respondError(w, http.StatusBadRequest, "Already claimed")
-		return
-	}
-
-	// Calculate payout
-	totalPool := market.YesPool + market.NoPool
-	var payout float64
-
-	if *market.WinningOutcome == models.OutcomeYes && position.YesShares > 0 {
-		payout = math.Floor((totalPool * position.YesShares) / market.TotalYesShares)
-	} else if *market.WinningOutcome == models.OutcomeNo && position.NoShares > 0 {
-		payout = math.Floor((totalPool * position.NoShares) / market.TotalNoShares)
-	}
-
-	if payout == 0 {
-		respondError(w, http.StatusBadRequest, "No winnings to claim")
-		return
-	}
-
-	position.Claimed = true
-	if err := h.storage.SavePosition(position); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to save position")
-		return
-	}
-
-	if err := h.storage.UpdateBalance(payout); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update balance")
-		return
-	}
-
-	balance, _ := h.storage.GetBalance()
-	respondJSON(w, http.StatusOK, models.ClaimResponse{
-		Success: true,
-		Payout:  payout,
-		Balance: balance,
-	})
+	respondJSON(w, http.StatusOK, resp)
 }
 
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -462,56 +262,49 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
-func respondError(w http.ResponseWriter, status int, message string) {
-	respondJSON(w, status, models.ErrorResponse{Error: message})
+// createMarketRequest is the body of POST /api/markets and the createMarket mutation input
+type createMarketRequest struct {
+	Question         string   `json:"question"`
+	Category         string   `json:"category"`
+	Tags             []string `json:"tags"`
+	EndTime          string   `json:"endTime"`
+	ResolutionSource string   `json:"resolutionSource"`
 }
 
 func (h *Handler) CreateMarket(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Question string `json:"question"`
-		Category string `json:"category"`
-		EndTime  string `json:"endTime"`
-	}
-
+	var req createMarketRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+		respondError(w, http.StatusBadRequest, CodeInvalidBody, "Invalid request body")
 		return
 	}
 
-	endTime, err := time.Parse(time.RFC3339, req.EndTime)
+	market, err := h.createMarket(r.Context(), req)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid end time format")
+		respondFailure(w, err, "Failed to create market")
 		return
 	}
 
-	market := &models.Market{
-		Question:       req.Question,
-		Category:       req.Category,
-		Status:         models.StatusActive,
-		EndTime:        endTime,
-		YesPool:        0,
-		NoPool:         0,
-		TotalYesShares: 0,
-		TotalNoShares:  0,
-		CreatedAt:      time.Now(),
-	}
-
-	if err := h.storage.SaveMarket(market); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create market")
-		return
-	}
+	respondJSON(w, http.StatusCreated, market)
+}
 
-	// Sync to Linera contract (async, best-effort)
-	if h.lineraClient.IsEnabled() {
-		go func() {
-			if err := h.lineraClient.CreateMarket(market.Question, market.Category, market.EndTime); err != nil {
-				log.Printf("⚠️  Failed to sync market creation to Linera: %v", err)
-			} else {
-				log.Printf("✅ Synced market creation to Linera: %s", market.Question)
-			}
-		}()
+// createMarket parses the end time of a create request and creates the market
+func (h *Handler) createMarket(ctx context.Context, req createMarketRequest) (*models.Market, error) {
+	var endTime time.Time
+	if req.EndTime != "" {
+		t, err := time.Parse(time.RFC3339, req.EndTime)
+		if err != nil {
+			return nil, validation.Errors{{
+				Field: "endTime", Code: validation.CodeInvalid, Message: "end time must be an RFC3339 timestamp",
+			}}
+		}
+		endTime = t
 	}
 
-	respondJSON(w, http.StatusCreated, market)
+	return h.markets.Create(ctx, market.CreateRequest{
+		Question:         req.Question,
+		Category:         req.Category,
+		Tags:             req.Tags,
+		EndTime:          endTime,
+		ResolutionSource: req.ResolutionSource,
+	})
 }
-
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/linera-prediction-market/backend/internal/history"
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// priceHistory is a market's Yes-probability candles over a time range
+type priceHistory struct {
+	MarketID int             `json:"marketId"`
+	Interval string          `json:"interval"`
+	From     time.Time       `json:"from"`
+	To       time.Time       `json:"to"`
+	Candles  []models.Candle `json:"candles"`
+}
+
+// GetMarketHistory returns OHLC candles of a market's Yes probability.
+// Query parameters: interval (1m, 5m, 15m, 1h, 4h, 1d; default 1h) and an
+// optional RFC3339 from/to range defaulting to the market's lifetime.
+func (h *Handler) GetMarketHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid market ID")
+		return
+	}
+
+	var from, to time.Time
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		if from, err = time.Parse(time.RFC3339, fromStr); err != nil {
+			respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid from time format")
+			return
+		}
+	}
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		if to, err = time.Parse(time.RFC3339, toStr); err != nil {
+			respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid to time format")
+			return
+		}
+	}
+
+	result, err := h.marketHistory(r.Context(), id, r.URL.Query().Get("interval"), from, to)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch price history")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// marketHistory builds a market's candles. An empty interval means 1h and a
+// zero from/to defaults to the market's lifetime, limited to the most recent
+// MaxCandles buckets.
+func (h *Handler) marketHistory(ctx context.Context, id int, intervalName string, from, to time.Time) (*priceHistory, error) {
+	if intervalName == "" {
+		intervalName = "1h"
+	}
+	interval, err := history.ParseInterval(intervalName)
+	if err != nil {
+		return nil, &apiError{http.StatusBadRequest, CodeInvalidParameter, err.Error()}
+	}
+
+	market, err := h.storage.GetMarket(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch market: %w", err)
+	}
+	if market == nil {
+		return nil, errMarketNotFound
+	}
+
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = market.CreatedAt.UTC()
+		if earliest := to.Add(-interval * (history.MaxCandles - 1)); from.Before(earliest) {
+			from = earliest
+		}
+	}
+
+	from = from.UTC().Truncate(interval)
+	to = to.UTC()
+	if !to.After(from) {
+		return nil, &apiError{http.StatusBadRequest, CodeInvalidParameter, "Time range is empty"}
+	}
+	if to.Sub(from)/interval > history.MaxCandles {
+		return nil, &apiError{http.StatusBadRequest, CodeInvalidParameter, "Time range too large for interval"}
+	}
+
+	snapshots, err := h.storage.GetPriceHistory(ctx, id, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch price history: %w", err)
+	}
+
+	return &priceHistory{
+		MarketID: id,
+		Interval: intervalName,
+		From:     from,
+		To:       to,
+		Candles:  history.BuildCandles(snapshots, interval),
+	}, nil
+}
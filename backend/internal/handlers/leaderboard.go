@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/linera-prediction-market/backend/internal/leaderboard"
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// GetLeaderboard returns the materialized leaderboard.
+// Query parameters: metric (volume, profit, winRate, roi, brier; default
+// profit), period (all, 30d, 7d, 24h; default all) and limit (default 50).
+func (h *Handler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	metric := r.URL.Query().Get("metric")
+	if metric == "" {
+		metric = "profit"
+	}
+	if !leaderboard.IsMetric(metric) {
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid metric")
+		return
+	}
+
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = "all"
+	}
+	if _, ok := leaderboard.Periods[period]; !ok {
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid period")
+		return
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 || l > 200 {
+			respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid limit (1-200)")
+			return
+		}
+		limit = l
+	}
+
+	entries, err := h.storage.GetLeaderboard(r.Context(), period, metric, limit)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch leaderboard")
+		return
+	}
+	if entries == nil {
+		entries = []*models.LeaderboardEntry{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"metric":  metric,
+		"period":  period,
+		"entries": entries,
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/portfolio"
+)
+
+// GetPortfolio returns every position valued at current odds with P&L totals
+func (h *Handler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
+	result, err := h.portfolio(r.Context())
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch portfolio")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// portfolio values the default user's positions against their markets
+func (h *Handler) portfolio(ctx context.Context) (*models.Portfolio, error) {
+	positions, err := h.storage.GetPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+
+	ids := make([]int, 0, len(positions))
+	for _, position := range positions {
+		ids = append(ids, position.MarketID)
+	}
+	found, err := h.storage.GetMarketsByID(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch markets: %w", err)
+	}
+
+	markets := make(map[int]*models.Market, len(found))
+	for _, market := range found {
+		markets[market.ID] = market
+	}
+
+	balance, err := h.storage.GetBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+
+	return portfolio.Build(positions, markets, balance), nil
+}
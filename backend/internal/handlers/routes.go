@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/linera-prediction-market/backend/internal/openapi"
+)
+
+// Register adds every API route to api, which is mounted under /api. Keep
+// internal/openapi/openapi.json in sync when adding or changing routes.
+func (h *Handler) Register(api *mux.Router) {
+	api.HandleFunc("/markets", h.GetMarkets).Methods("GET")
+	api.HandleFunc("/markets", h.CreateMarket).Methods("POST")
+	api.HandleFunc("/markets/search", h.SearchMarkets).Methods("GET")
+	api.HandleFunc("/markets/{id}", h.GetMarket).Methods("GET")
+	api.HandleFunc("/markets/{id}/resolve", h.admin(h.ResolveMarket)).Methods("POST")
+	api.HandleFunc("/markets/{id}/history", h.GetMarketHistory).Methods("GET")
+	api.HandleFunc("/markets/{id}/trades", h.GetMarketTrades).Methods("GET")
+	api.HandleFunc("/markets/{id}/tags", h.admin(h.SetMarketTags)).Methods("PUT")
+	api.HandleFunc("/categories", h.GetCategories).Methods("GET")
+	api.HandleFunc("/categories", h.admin(h.CreateCategory)).Methods("POST")
+	api.HandleFunc("/categories/{id}", h.admin(h.UpdateCategory)).Methods("PUT")
+	api.HandleFunc("/categories/{id}", h.admin(h.DeleteCategory)).Methods("DELETE")
+	api.HandleFunc("/trades", h.GetTrades).Methods("GET")
+	api.HandleFunc("/leaderboard", h.GetLeaderboard).Methods("GET")
+	api.HandleFunc("/positions", h.GetPositions).Methods("GET")
+	api.HandleFunc("/balance", h.GetBalance).Methods("GET")
+	api.HandleFunc("/portfolio", h.GetPortfolio).Methods("GET")
+	api.HandleFunc("/bet", h.PlaceBet).Methods("POST")
+	api.HandleFunc("/claim/{marketId}", h.ClaimWinnings).Methods("POST")
+	api.HandleFunc("/ws", h.MarketsWebSocket).Methods("GET")
+	api.HandleFunc("/stream", h.Stream).Methods("GET")
+	api.HandleFunc("/graphql", h.GraphQL).Methods("GET", "POST")
+	api.HandleFunc("/openapi.json", openapi.Handler).Methods("GET")
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// SearchMarkets returns markets whose question matches ?q=, best match first.
+// It accepts the same status, category and tag filters as GetMarkets.
+func (h *Handler) SearchMarkets(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Missing search query")
+		return
+	}
+
+	query := models.MarketSearch{
+		Query:    q,
+		Status:   r.URL.Query().Get("status"),
+		Category: r.URL.Query().Get("category"),
+		Tag:      r.URL.Query().Get("tag"),
+		Limit:    20,
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 || l > 100 {
+			respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid limit (1-100)")
+			return
+		}
+		query.Limit = l
+	}
+
+	markets, err := h.storage.SearchMarkets(r.Context(), query)
+	if err != nil {
+		respondFailure(w, err, "Failed to search markets")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"query":   q,
+		"markets": markets,
+	})
+}
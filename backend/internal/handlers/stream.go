@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+const (
+	sseHeartbeat   = 15 * time.Second
+	sseReplayBatch = 500
+)
+
+// Stream serves domain events as Server-Sent Events.
+// Reconnecting clients send the Last-Event-ID header (or ?lastEventId=) and
+// receive every persisted event after that sequence number before the live feed.
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondError(w, http.StatusInternalServerError, CodeInternal, "Streaming not supported")
+		return
+	}
+
+	lastSeq := int64(0)
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+	if lastEventID != "" {
+		seq, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || seq < 0 {
+			respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid Last-Event-ID")
+			return
+		}
+		lastSeq = seq
+	}
+
+	// Subscribe before replaying so no event falls between the two
+	sub := h.events.Subscribe(0)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+	flusher.Flush()
+
+	// replay writes every persisted event after lastSeq. It reports false when
+	// the stream has to end: on a failed read the client reconnects with
+	// Last-Event-ID rather than carrying on with a gap.
+	replay := func() bool {
+		for {
+			missed, err := h.events.EventsSince(r.Context(), lastSeq, sseReplayBatch)
+			if err != nil {
+				log.Printf("⚠️  Failed to replay events after #%d: %v", lastSeq, err)
+				return false
+			}
+			for _, event := range missed {
+				if err := writeSSE(w, event); err != nil {
+					return false
+				}
+				lastSeq = event.Seq
+			}
+			flusher.Flush()
+			if len(missed) < sseReplayBatch {
+				return true
+			}
+		}
+	}
+
+	// Without Last-Event-ID there is nothing to resume from until the first
+	// sequenced event has been delivered
+	resuming := lastEventID != ""
+	if resuming && !replay() {
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event := <-sub.C:
+			if event.Seq != 0 {
+				// Already delivered during replay
+				if resuming && event.Seq <= lastSeq {
+					continue
+				}
+				// The bus drops events for subscribers that fall behind, so
+				// fill the gap from the store before going on
+				if resuming && event.Seq > lastSeq+1 {
+					if !replay() {
+						return
+					}
+					if event.Seq <= lastSeq {
+						continue
+					}
+				}
+			}
+			if err := writeSSE(w, event); err != nil {
+				return
+			}
+			if event.Seq != 0 {
+				lastSeq = event.Seq
+				resuming = true
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-sub.Done():
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, event models.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.Seq != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.Seq); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/events"
+	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/storage"
+)
+
+// streamStore wraps the in-memory store so tests can act while a replay is
+// running or make it fail
+type streamStore struct {
+	*storage.Storage
+	beforeRead func()
+	fail       atomic.Bool
+}
+
+func (s *streamStore) GetEventsSince(ctx context.Context, afterSeq int64, limit int) ([]models.Event, error) {
+	if s.fail.Load() {
+		return nil, errors.New("database is down")
+	}
+	if s.beforeRead != nil {
+		s.beforeRead()
+		s.beforeRead = nil
+	}
+	return s.Storage.GetEventsSince(ctx, afterSeq, limit)
+}
+
+// openStream connects to the SSE endpoint and returns the IDs of the events
+// it receives; the channel is closed when the server ends the stream
+func openStream(t *testing.T, bus *events.Bus, lastEventID string) <-chan int64 {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(New(nil, nil, bus).Stream))
+	t.Cleanup(srv.Close)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	ids := make(chan int64, 16)
+	go func() {
+		defer close(ids)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if line, ok := strings.CutPrefix(scanner.Text(), "id: "); ok {
+				id, _ := strconv.ParseInt(line, 10, 64)
+				ids <- id
+			}
+		}
+	}()
+	return ids
+}
+
+// expectIDs checks the next events on the stream have the given IDs
+func expectIDs(t *testing.T, ids <-chan int64, want ...int64) {
+	t.Helper()
+	for _, w := range want {
+		select {
+		case id, ok := <-ids:
+			if !ok {
+				t.Fatalf("stream ended, want event #%d", w)
+			}
+			if id != w {
+				t.Fatalf("got event #%d, want #%d", id, w)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for event #%d", w)
+		}
+	}
+}
+
+// expectClosed checks the server ends the stream without sending anything else
+func expectClosed(t *testing.T, ids <-chan int64) {
+	t.Helper()
+	select {
+	case id, ok := <-ids:
+		if ok {
+			t.Fatalf("got event #%d, want the stream to end", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the stream to end")
+	}
+}
+
+func appendEvents(t *testing.T, store *streamStore, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		event := events.BalanceEvent(1, 100)
+		if err := store.AppendEvent(context.Background(), &event); err != nil {
+			t.Fatalf("AppendEvent: %v", err)
+		}
+	}
+}
+
+func TestStreamReplaysAfterLastEventID(t *testing.T) {
+	store := &streamStore{Storage: storage.New()}
+	bus := events.NewBus(store)
+	appendEvents(t, store, 3)
+
+	ids := openStream(t, bus, "1")
+	expectIDs(t, ids, 2, 3)
+
+	bus.Publish(events.BalanceEvent(1, 100))
+	expectIDs(t, ids, 4)
+}
+
+func TestStreamSkipsReplayedEvents(t *testing.T) {
+	store := &streamStore{Storage: storage.New()}
+	bus := events.NewBus(store)
+	appendEvents(t, store, 2)
+
+	// Published after the handler subscribed but before it read the store,
+	// so #3 arrives both in the replay and on the live feed
+	store.beforeRead = func() { bus.Publish(events.BalanceEvent(1, 100)) }
+
+	ids := openStream(t, bus, "0")
+	expectIDs(t, ids, 1, 2, 3)
+
+	bus.Publish(events.BalanceEvent(1, 100))
+	expectIDs(t, ids, 4)
+}
+
+func TestStreamBackfillsDroppedEvents(t *testing.T) {
+	store := &streamStore{Storage: storage.New()}
+	bus := events.NewBus(store)
+
+	ids := openStream(t, bus, "")
+	bus.Publish(events.BalanceEvent(1, 100))
+	expectIDs(t, ids, 1)
+
+	// #2 is persisted but never reaches the subscriber, as when the bus
+	// drops it for a full buffer
+	appendEvents(t, store, 1)
+	bus.Publish(events.BalanceEvent(1, 100))
+	expectIDs(t, ids, 2, 3)
+}
+
+func TestStreamClosesWhenReplayFails(t *testing.T) {
+	t.Run("on connect", func(t *testing.T) {
+		store := &streamStore{Storage: storage.New()}
+		bus := events.NewBus(store)
+		appendEvents(t, store, 2)
+		store.fail.Store(true)
+
+		expectClosed(t, openStream(t, bus, "0"))
+	})
+
+	t.Run("filling a gap", func(t *testing.T) {
+		store := &streamStore{Storage: storage.New()}
+		bus := events.NewBus(store)
+
+		ids := openStream(t, bus, "")
+		bus.Publish(events.BalanceEvent(1, 100))
+		expectIDs(t, ids, 1)
+
+		appendEvents(t, store, 1)
+		store.fail.Store(true)
+		bus.Publish(events.BalanceEvent(1, 100))
+		expectClosed(t, ids)
+	})
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// GetMarketTrades returns the trade tape of a single market, newest first
+func (h *Handler) GetMarketTrades(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid market ID")
+		return
+	}
+
+	market, err := h.storage.GetMarket(r.Context(), id)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch market")
+		return
+	}
+	if market == nil {
+		respondError(w, http.StatusNotFound, CodeMarketNotFound, "Market not found")
+		return
+	}
+
+	h.respondTrades(w, r, models.TradeQuery{MarketID: id})
+}
+
+// GetTrades returns trades across all markets, optionally filtered by ?user=
+func (h *Handler) GetTrades(w http.ResponseWriter, r *http.Request) {
+	h.respondTrades(w, r, models.TradeQuery{User: r.URL.Query().Get("user")})
+}
+
+// respondTrades applies ?cursor= and ?limit= to the query and writes a page of trades.
+// The cursor is the ID of the last trade on the previous page.
+func (h *Handler) respondTrades(w http.ResponseWriter, r *http.Request, query models.TradeQuery) {
+	query.Limit = 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 || l > 200 {
+			respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid limit (1-200)")
+			return
+		}
+		query.Limit = l
+	}
+
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		before, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || before <= 0 {
+			respondError(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid cursor")
+			return
+		}
+		query.Before = before
+	}
+
+	trades, nextCursor, err := h.tradePage(r.Context(), query)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch trades")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"trades":     trades,
+		"nextCursor": nextCursor,
+	})
+}
+
+// tradePage fetches up to query.Limit trades and the cursor of the next page, if any
+func (h *Handler) tradePage(ctx context.Context, query models.TradeQuery) ([]*models.Trade, *string, error) {
+	// Fetch one extra row to know whether another page exists
+	pageSize := query.Limit
+	query.Limit++
+
+	trades, err := h.storage.GetTrades(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch trades: %w", err)
+	}
+
+	var nextCursor *string
+	if len(trades) > pageSize {
+		trades = trades[:pageSize]
+		cursor := strconv.FormatInt(trades[pageSize-1].ID, 10)
+		nextCursor = &cursor
+	}
+	if trades == nil {
+		trades = []*models.Trade{}
+	}
+	return trades, nextCursor, nil
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = (wsPongWait * 9) / 10
+	wsMaxMessage = 4096
+)
+
+// wsClientMessage is a subscription request sent by a WebSocket client.
+//
+//	{"action": "subscribe", "marketIds": [1, 2]}
+//	{"action": "subscribe", "all": true}
+//	{"action": "unsubscribe", "marketIds": [2]}
+type wsClientMessage struct {
+	Action    string `json:"action"`
+	MarketIDs []int  `json:"marketIds"`
+	All       bool   `json:"all"`
+}
+
+// wsServerMessage acknowledges a subscription change or reports an error
+type wsServerMessage struct {
+	Type      string `json:"type"`
+	All       bool   `json:"all"`
+	MarketIDs []int  `json:"marketIds"`
+	Error     string `json:"error,omitempty"`
+}
+
+// wsSubscriptions tracks which markets a single connection wants to hear about
+type wsSubscriptions struct {
+	mu      sync.RWMutex
+	all     bool
+	markets map[int]bool
+}
+
+func (s *wsSubscriptions) matches(event models.Event) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.all || s.markets[event.MarketID]
+}
+
+func (s *wsSubscriptions) apply(msg wsClientMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch msg.Action {
+	case "subscribe":
+		if msg.All {
+			s.all = true
+		}
+		for _, id := range msg.MarketIDs {
+			s.markets[id] = true
+		}
+	case "unsubscribe":
+		if msg.All {
+			s.all = false
+			s.markets = make(map[int]bool)
+		}
+		for _, id := range msg.MarketIDs {
+			delete(s.markets, id)
+		}
+	}
+}
+
+func (s *wsSubscriptions) ack() wsServerMessage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]int, 0, len(s.markets))
+	for id := range s.markets {
+		ids = append(ids, id)
+	}
+	return wsServerMessage{Type: "subscribed", All: s.all, MarketIDs: ids}
+}
+
+// MarketsWebSocket streams market events to WebSocket clients.
+// Clients may pre-subscribe with ?markets=all or ?markets=1,2,3 and change
+// their subscriptions at any time by sending subscribe/unsubscribe messages.
+func (h *Handler) MarketsWebSocket(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     h.checkOrigin,
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("⚠️  WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	subs := &wsSubscriptions{markets: make(map[int]bool)}
+	if param := r.URL.Query().Get("markets"); param != "" {
+		subs.apply(parseMarketsParam(param))
+	}
+
+	sub := h.events.Subscribe(0)
+	defer sub.Close()
+
+	// Reader: processes subscription messages and detects disconnects
+	incoming := make(chan wsServerMessage, 8)
+	go func() {
+		defer sub.Close()
+
+		conn.SetReadLimit(wsMaxMessage)
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		})
+
+		for {
+			var msg wsClientMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+					log.Printf("🔌 WebSocket client disconnected: %v", err)
+				}
+				return
+			}
+
+			var reply wsServerMessage
+			if msg.Action != "subscribe" && msg.Action != "unsubscribe" {
+				reply = wsServerMessage{Type: "error", Error: "Unknown action: " + msg.Action}
+			} else {
+				subs.apply(msg)
+				reply = subs.ack()
+			}
+
+			select {
+			case incoming <- reply:
+			case <-sub.Done():
+				return
+			}
+		}
+	}()
+
+	// Writer: the only goroutine writing to the connection
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	if err := writeWS(conn, subs.ack()); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case event := <-sub.C:
+			if !subs.matches(event) {
+				continue
+			}
+			if err := writeWS(conn, event); err != nil {
+				return
+			}
+		case reply := <-incoming:
+			if err := writeWS(conn, reply); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-sub.Done():
+			return
+		}
+	}
+}
+
+func (h *Handler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range h.allowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+func writeWS(conn *websocket.Conn, v interface{}) error {
+	conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return conn.WriteJSON(v)
+}
+
+// parseMarketsParam converts "all" or "1,2,3" into a subscribe message
+func parseMarketsParam(param string) wsClientMessage {
+	msg := wsClientMessage{Action: "subscribe"}
+	if param == "all" {
+		msg.All = true
+		return msg
+	}
+	for _, part := range strings.Split(param, ",") {
+		if id, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			msg.MarketIDs = append(msg.MarketIDs, id)
+		}
+	}
+	return msg
+}
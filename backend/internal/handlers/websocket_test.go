@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/linera-prediction-market/backend/internal/events"
+)
+
+// wsMessage holds the fields of both event and acknowledgement messages
+type wsMessage struct {
+	Type      string `json:"type"`
+	MarketID  int    `json:"marketId"`
+	All       bool   `json:"all"`
+	MarketIDs []int  `json:"marketIds"`
+	Error     string `json:"error"`
+}
+
+func dialMarkets(t *testing.T, bus *events.Bus, query string) *websocket.Conn {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(New(nil, nil, bus).MarketsWebSocket))
+	t.Cleanup(srv.Close)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readWS(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg wsMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	return msg
+}
+
+func TestMarketsWebSocketFiltersByMarket(t *testing.T) {
+	bus := events.NewBus(nil)
+	conn := dialMarkets(t, bus, "?markets=1")
+
+	if ack := readWS(t, conn); ack.Type != "subscribed" || ack.All || len(ack.MarketIDs) != 1 || ack.MarketIDs[0] != 1 {
+		t.Fatalf("first message = %+v, want an acknowledgement of market 1", ack)
+	}
+
+	bus.Publish(events.BalanceEvent(2, 100))
+	bus.Publish(events.BalanceEvent(1, 100))
+	if msg := readWS(t, conn); msg.MarketID != 1 {
+		t.Fatalf("got an event for market %d, want only market 1", msg.MarketID)
+	}
+
+	// Switch from market 1 to market 2
+	if err := conn.WriteJSON(wsClientMessage{Action: "subscribe", MarketIDs: []int{2}}); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	readWS(t, conn)
+	if err := conn.WriteJSON(wsClientMessage{Action: "unsubscribe", MarketIDs: []int{1}}); err != nil {
+		t.Fatalf("failed to unsubscribe: %v", err)
+	}
+	if ack := readWS(t, conn); len(ack.MarketIDs) != 1 || ack.MarketIDs[0] != 2 {
+		t.Fatalf("acknowledgement = %+v, want market 2 only", ack)
+	}
+
+	bus.Publish(events.BalanceEvent(1, 100))
+	bus.Publish(events.BalanceEvent(2, 100))
+	if msg := readWS(t, conn); msg.MarketID != 2 {
+		t.Fatalf("got an event for market %d, want only market 2", msg.MarketID)
+	}
+}
+
+func TestMarketsWebSocketSubscribeAll(t *testing.T) {
+	bus := events.NewBus(nil)
+	conn := dialMarkets(t, bus, "")
+
+	if ack := readWS(t, conn); ack.All || len(ack.MarketIDs) != 0 {
+		t.Fatalf("first message = %+v, want no subscriptions", ack)
+	}
+
+	// Nothing is delivered before subscribing
+	bus.Publish(events.BalanceEvent(1, 100))
+
+	if err := conn.WriteJSON(wsClientMessage{Action: "subscribe", All: true}); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	if ack := readWS(t, conn); !ack.All {
+		t.Fatalf("acknowledgement = %+v, want all markets", ack)
+	}
+
+	bus.Publish(events.BalanceEvent(3, 100))
+	if msg := readWS(t, conn); msg.MarketID != 3 {
+		t.Fatalf("got an event for market %d, want market 3", msg.MarketID)
+	}
+}
+
+func TestMarketsWebSocketUnknownAction(t *testing.T) {
+	conn := dialMarkets(t, events.NewBus(nil), "")
+	readWS(t, conn)
+
+	if err := conn.WriteJSON(wsClientMessage{Action: "watch"}); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+	if msg := readWS(t, conn); msg.Type != "error" || msg.Error != "Unknown action: watch" {
+		t.Fatalf("reply = %+v, want an unknown action error", msg)
+	}
+}
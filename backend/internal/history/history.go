@@ -0,0 +1,101 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// MaxCandles caps the number of candles returned for a single request
+const MaxCandles = 2000
+
+// intervals lists the candle widths accepted by the history endpoint
+var intervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// ParseInterval converts an interval name such as "1h" into a duration
+func ParseInterval(name string) (time.Duration, error) {
+	interval, ok := intervals[name]
+	if !ok {
+		return 0, fmt.Errorf("unsupported interval %q (use 1m, 5m, 15m, 1h, 4h or 1d)", name)
+	}
+	return interval, nil
+}
+
+// Snapshot records the market's probability right after a bet of the given size
+func Snapshot(market *models.Market, volume float64) *models.PriceSnapshot {
+	yes := models.CalculateOdds(market).Yes
+
+	return &models.PriceSnapshot{
+		MarketID:  market.ID,
+		Timestamp: time.Now().UTC(),
+		Open:      yes,
+		High:      yes,
+		Low:       yes,
+		Close:     yes,
+		Volume:    volume,
+		YesPool:   market.YesPool,
+		NoPool:    market.NoPool,
+	}
+}
+
+// BuildCandles aggregates snapshots (ordered by timestamp) into candles of the
+// given interval. Buckets without trades between the first and last snapshot
+// are carried forward from the previous close with zero volume.
+func BuildCandles(snapshots []*models.PriceSnapshot, interval time.Duration) []models.Candle {
+	if len(snapshots) == 0 {
+		return []models.Candle{}
+	}
+
+	var candles []models.Candle
+	var current *models.Candle
+
+	for _, s := range snapshots {
+		bucket := s.Timestamp.UTC().Truncate(interval)
+
+		if current != nil && bucket.Equal(current.Time) {
+			if s.High > current.High {
+				current.High = s.High
+			}
+			if s.Low < current.Low {
+				current.Low = s.Low
+			}
+			current.Close = s.Close
+			current.Volume += s.Volume
+			continue
+		}
+
+		if current != nil {
+			candles = append(candles, *current)
+
+			// Fill gaps with flat candles at the previous close
+			for t := current.Time.Add(interval); t.Before(bucket); t = t.Add(interval) {
+				candles = append(candles, models.Candle{
+					Time:  t,
+					Open:  current.Close,
+					High:  current.Close,
+					Low:   current.Close,
+					Close: current.Close,
+				})
+			}
+		}
+
+		current = &models.Candle{
+			Time:   bucket,
+			Open:   s.Open,
+			High:   s.High,
+			Low:    s.Low,
+			Close:  s.Close,
+			Volume: s.Volume,
+		}
+	}
+
+	return append(candles, *current)
+}
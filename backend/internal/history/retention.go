@@ -0,0 +1,100 @@
+package history
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// RetentionStore defines the storage operations used by the retention worker
+type RetentionStore interface {
+	DownsamplePriceHistory(ctx context.Context, before time.Time, resolution time.Duration) (int64, error)
+	DeletePriceHistoryBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RetentionPolicy controls how long price history is kept at each resolution
+type RetentionPolicy struct {
+	RawFor       time.Duration // keep raw per-bet snapshots this long
+	DownsampleTo time.Duration // bucket width for older snapshots
+	KeepFor      time.Duration // delete history older than this
+	RunEvery     time.Duration
+}
+
+// DefaultRetentionPolicy keeps raw snapshots for 7 days, hourly buckets for 90 days
+var DefaultRetentionPolicy = RetentionPolicy{
+	RawFor:       7 * 24 * time.Hour,
+	DownsampleTo: time.Hour,
+	KeepFor:      90 * 24 * time.Hour,
+	RunEvery:     time.Hour,
+}
+
+// Retention periodically downsamples and prunes price history
+type Retention struct {
+	store  RetentionStore
+	policy RetentionPolicy
+	ticker *time.Ticker
+	done   chan bool
+	wg     sync.WaitGroup
+}
+
+// NewRetention creates a retention worker for the given policy
+func NewRetention(s RetentionStore, policy RetentionPolicy) *Retention {
+	return &Retention{
+		store:  s,
+		policy: policy,
+		done:   make(chan bool),
+	}
+}
+
+// Start runs the retention pass immediately and then on every tick
+func (r *Retention) Start() {
+	log.Printf("🗜️  Price history retention started (raw %s, %s buckets, keep %s)",
+		r.policy.RawFor, r.policy.DownsampleTo, r.policy.KeepFor)
+
+	r.ticker = time.NewTicker(r.policy.RunEvery)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.Run(context.Background())
+		for {
+			select {
+			case <-r.ticker.C:
+				r.Run(context.Background())
+			case <-r.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the retention worker, waiting for a pass in progress to finish
+func (r *Retention) Stop() {
+	if r.ticker != nil {
+		r.ticker.Stop()
+	}
+	close(r.done)
+	r.wg.Wait()
+}
+
+// Run performs a single downsample and prune pass
+func (r *Retention) Run(ctx context.Context) {
+	now := time.Now().UTC()
+
+	// Align the cutoff to a bucket boundary so no bucket is split
+	cutoff := now.Add(-r.policy.RawFor).Truncate(r.policy.DownsampleTo)
+	merged, err := r.store.DownsamplePriceHistory(ctx, cutoff, r.policy.DownsampleTo)
+	if err != nil {
+		log.Printf("❌ Failed to downsample price history: %v", err)
+	} else if merged > 0 {
+		log.Printf("🗜️  Downsampled price history before %s into %d bucket(s)", cutoff.Format(time.RFC3339), merged)
+	}
+
+	deleted, err := r.store.DeletePriceHistoryBefore(ctx, now.Add(-r.policy.KeepFor))
+	if err != nil {
+		log.Printf("❌ Failed to prune price history: %v", err)
+	} else if deleted > 0 {
+		log.Printf("🗑️  Pruned %d expired price history row(s)", deleted)
+	}
+}
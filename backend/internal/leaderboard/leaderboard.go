@@ -0,0 +1,221 @@
+package leaderboard
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/storage"
+)
+
+// Periods maps each leaderboard period to its look-back window (0 means all time)
+var Periods = map[string]time.Duration{
+	"all": 0,
+	"30d": 30 * 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"24h": 24 * time.Hour,
+}
+
+// Metrics lists the sortable leaderboard metrics
+var Metrics = []string{"volume", "profit", "winRate", "roi", "brier"}
+
+// StorageInterface defines the storage operations used by the leaderboard
+type StorageInterface interface {
+	GetMarkets(ctx context.Context) ([]*models.Market, error)
+	GetTradesSince(ctx context.Context, since time.Time) ([]*models.Trade, error)
+	ReplaceLeaderboard(ctx context.Context, period string, entries []*models.LeaderboardEntry) error
+}
+
+// Service periodically recomputes the materialized leaderboard
+type Service struct {
+	storage StorageInterface
+	ticker  *time.Ticker
+	done    chan bool
+	wg      sync.WaitGroup
+}
+
+// NewService creates a new leaderboard service
+func NewService(s StorageInterface) *Service {
+	return &Service{
+		storage: s,
+		done:    make(chan bool),
+	}
+}
+
+// Start refreshes the leaderboard immediately and then every interval
+func (s *Service) Start(interval time.Duration) {
+	log.Printf("🏆 Leaderboard service started (refresh every %s)", interval)
+
+	s.ticker = time.NewTicker(interval)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.refreshAndLog()
+		for {
+			select {
+			case <-s.ticker.C:
+				s.refreshAndLog()
+			case <-s.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the leaderboard service, waiting for a refresh in progress to finish
+func (s *Service) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	close(s.done)
+	s.wg.Wait()
+}
+
+func (s *Service) refreshAndLog() {
+	if err := s.Refresh(context.Background()); err != nil {
+		log.Printf("❌ Failed to refresh leaderboard: %v", err)
+	}
+}
+
+// Refresh recomputes every period and replaces the materialized rows
+func (s *Service) Refresh(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	markets, err := s.storage.GetMarkets(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*models.Market, len(markets))
+	for _, m := range markets {
+		byID[m.ID] = m
+	}
+
+	for period, window := range Periods {
+		since := time.Time{}
+		if window > 0 {
+			since = now.Add(-window)
+		}
+
+		trades, err := s.storage.GetTradesSince(ctx, since)
+		if err != nil {
+			return err
+		}
+
+		entries := Compute(trades, byID)
+		for _, e := range entries {
+			e.Period = period
+			e.RefreshedAt = now
+		}
+
+		if err := s.storage.ReplaceLeaderboard(ctx, period, entries); err != nil {
+			return fmt.Errorf("period %s: %w", period, err)
+		}
+	}
+
+	return nil
+}
+
+// holding aggregates one user's trades in one market
+type holding struct {
+	yesShares, noShares float64
+	yesAmount, noAmount float64
+}
+
+// Compute derives per-user statistics from trades.
+// Realized profit, win rate, ROI and Brier score only consider resolved
+// markets. The Brier score treats the user's stake-weighted share on Yes as
+// their forecast of the Yes outcome; lower is better.
+func Compute(trades []*models.Trade, markets map[int]*models.Market) []*models.LeaderboardEntry {
+	entries := make(map[string]*models.LeaderboardEntry)
+	holdings := make(map[string]map[int]*holding)
+
+	for _, t := range trades {
+		e, ok := entries[t.User]
+		if !ok {
+			e = &models.LeaderboardEntry{User: t.User}
+			entries[t.User] = e
+			holdings[t.User] = make(map[int]*holding)
+		}
+		e.Volume += t.Amount
+		e.Trades++
+
+		h, ok := holdings[t.User][t.MarketID]
+		if !ok {
+			h = &holding{}
+			holdings[t.User][t.MarketID] = h
+		}
+		if t.Outcome == models.OutcomeYes {
+			h.yesShares += t.Shares
+			h.yesAmount += t.Amount
+		} else {
+			h.noShares += t.Shares
+			h.noAmount += t.Amount
+		}
+	}
+
+	result := make([]*models.LeaderboardEntry, 0, len(entries))
+	for user, e := range entries {
+		var resolvedCost, brierSum float64
+
+		for marketID, h := range holdings[user] {
+			market, ok := markets[marketID]
+			if !ok || market.Status != models.StatusResolved || market.WinningOutcome == nil {
+				continue
+			}
+
+			cost := h.yesAmount + h.noAmount
+			if cost == 0 {
+				continue
+			}
+			payout := storage.CalculatePayout(market, &models.UserPosition{
+				MarketID:  marketID,
+				YesShares: h.yesShares,
+				NoShares:  h.noShares,
+			})
+			profit := payout - cost
+
+			e.ResolvedMarkets++
+			e.RealizedProfit += profit
+			resolvedCost += cost
+			if profit > 0 {
+				e.Wins++
+			}
+
+			forecast := h.yesAmount / cost
+			actual := 0.0
+			if *market.WinningOutcome == models.OutcomeYes {
+				actual = 1
+			}
+			brierSum += (forecast - actual) * (forecast - actual)
+		}
+
+		if e.ResolvedMarkets > 0 {
+			e.WinRate = float64(e.Wins) / float64(e.ResolvedMarkets)
+			brier := brierSum / float64(e.ResolvedMarkets)
+			e.BrierScore = &brier
+		}
+		if resolvedCost > 0 {
+			e.ROI = e.RealizedProfit / resolvedCost
+		}
+
+		result = append(result, e)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].User < result[j].User })
+	return result
+}
+
+// IsMetric reports whether name is a sortable leaderboard metric
+func IsMetric(name string) bool {
+	for _, m := range Metrics {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
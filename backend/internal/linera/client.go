@@ -2,7 +2,9 @@ package linera
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -16,6 +18,14 @@ const (
 	RUST_SERVICE_URL   = "http://localhost:8081"
 )
 
+// Errors returned by the client wrap one of these, so callers can tell a
+// disabled integration from an unreachable or failing service with errors.Is
+var (
+	ErrDisabled    = errors.New("linera client is disabled")
+	ErrUnavailable = errors.New("linera service unavailable")
+	ErrRejected    = errors.New("linera service rejected the request")
+)
+
 // Client represents a Linera GraphQL client
 type Client struct {
 	endpoint   string
@@ -60,9 +70,9 @@ func (c *Client) IsEnabled() bool {
 }
 
 // Query executes a GraphQL query against the Linera contract
-func (c *Client) Query(query string, variables map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) Query(ctx context.Context, query string, variables map[string]interface{}) (map[string]interface{}, error) {
 	if !c.enabled {
-		return nil, fmt.Errorf("linera client is disabled")
+		return nil, ErrDisabled
 	}
 
 	req := GraphQLRequest{
@@ -75,7 +85,7 @@ func (c *Client) Query(query string, variables map[string]interface{}) (map[stri
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", c.endpoint, bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -84,7 +94,7 @@ func (c *Client) Query(query string, variables map[string]interface{}) (map[stri
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("%w: failed to execute request: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
@@ -94,7 +104,7 @@ func (c *Client) Query(query string, variables map[string]interface{}) (map[stri
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("%w: unexpected status code: %d, body: %s", ErrRejected, resp.StatusCode, string(body))
 	}
 
 	var graphQLResp GraphQLResponse
@@ -103,28 +113,28 @@ func (c *Client) Query(query string, variables map[string]interface{}) (map[stri
 	}
 
 	if len(graphQLResp.Errors) > 0 {
-		return nil, fmt.Errorf("graphql error: %s", graphQLResp.Errors[0].Message)
+		return nil, fmt.Errorf("%w: graphql error: %s", ErrRejected, graphQLResp.Errors[0].Message)
 	}
 
 	return graphQLResp.Data, nil
 }
 
 // Mutate executes a GraphQL mutation against the Linera contract
-func (c *Client) Mutate(mutation string, variables map[string]interface{}) error {
+func (c *Client) Mutate(ctx context.Context, mutation string, variables map[string]interface{}) error {
 	if !c.enabled {
 		log.Println("⚠️  Linera client is disabled, skipping mutation")
 		return nil
 	}
 
-	_, err := c.Query(mutation, variables)
+	_, err := c.Query(ctx, mutation, variables)
 	return err
 }
 
 // GetMarketCount queries the total number of markets on-chain
-func (c *Client) GetMarketCount() (int, error) {
+func (c *Client) GetMarketCount(ctx context.Context) (int, error) {
 	query := `{ marketCount }`
 	
-	data, err := c.Query(query, nil)
+	data, err := c.Query(ctx, query, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -138,7 +148,7 @@ func (c *Client) GetMarketCount() (int, error) {
 }
 
 // CreateMarket creates a new market on-chain
-func (c *Client) CreateMarket(question string, category string, endTime time.Time) error {
+func (c *Client) CreateMarket(ctx context.Context, question string, category string, endTime time.Time) error {
 	if !c.enabled {
 		return nil
 	}
@@ -151,11 +161,11 @@ func (c *Client) CreateMarket(question string, category string, endTime time.Tim
 		},
 	}
 
-	return c.submitOperation(operation)
+	return c.submitOperation(ctx, operation)
 }
 
 // PlaceBet places a bet on a market on-chain
-func (c *Client) PlaceBet(marketID int, outcome string, amount int) error {
+func (c *Client) PlaceBet(ctx context.Context, marketID int, outcome string, amount int) error {
 	if !c.enabled {
 		return nil
 	}
@@ -168,11 +178,11 @@ func (c *Client) PlaceBet(marketID int, outcome string, amount int) error {
 		},
 	}
 
-	return c.submitOperation(operation)
+	return c.submitOperation(ctx, operation)
 }
 
 // ResolveMarket resolves a market on-chain
-func (c *Client) ResolveMarket(marketID int, outcome string) error {
+func (c *Client) ResolveMarket(ctx context.Context, marketID int, outcome string) error {
 	if !c.enabled {
 		return nil
 	}
@@ -184,11 +194,11 @@ func (c *Client) ResolveMarket(marketID int, outcome string) error {
 		},
 	}
 
-	return c.submitOperation(operation)
+	return c.submitOperation(ctx, operation)
 }
 
 // submitOperation submits an operation to the Linera contract via Rust microservice
-func (c *Client) submitOperation(operation interface{}) error {
+func (c *Client) submitOperation(ctx context.Context, operation interface{}) error {
 	// Extract operation type and params
 	opMap, ok := operation.(map[string]interface{})
 	if !ok {
@@ -217,7 +227,7 @@ func (c *Client) submitOperation(operation interface{}) error {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -226,7 +236,7 @@ func (c *Client) submitOperation(operation interface{}) error {
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return fmt.Errorf("failed to call Rust service: %w", err)
+		return fmt.Errorf("%w: failed to call Rust service: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
@@ -236,7 +246,7 @@ func (c *Client) submitOperation(operation interface{}) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Rust service error (status %d): %s", resp.StatusCode, string(body))
+		return fmt.Errorf("%w: Rust service error (status %d): %s", ErrRejected, resp.StatusCode, string(body))
 	}
 
 	log.Printf("✅ Linera operation successful via Rust service: %s", string(body))
@@ -244,12 +254,12 @@ func (c *Client) submitOperation(operation interface{}) error {
 }
 
 // HealthCheck verifies connectivity to the Linera service
-func (c *Client) HealthCheck() error {
+func (c *Client) HealthCheck(ctx context.Context) error {
 	if !c.enabled {
-		return fmt.Errorf("linera client is disabled")
+		return ErrDisabled
 	}
 
-	_, err := c.GetMarketCount()
+	_, err := c.GetMarketCount(ctx)
 	return err
 }
 
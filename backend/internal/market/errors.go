@@ -0,0 +1,32 @@
+package market
+
+import "errors"
+
+// Error is a request the market rules refuse. Code is stable and is
+// returned as the error code by every API.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Errors returned by Service. Bets are also refused with a
+// *validation.BetError and new markets with validation.Errors.
+var (
+	ErrMarketNotFound    = &Error{Code: "market_not_found", Message: "Market not found"}
+	ErrUserNotFound      = &Error{Code: "user_not_found", Message: "User not found"}
+	ErrPositionNotFound  = &Error{Code: "position_not_found", Message: "Position not found"}
+	ErrMarketNotResolved = &Error{Code: "market_not_resolved", Message: "Market not resolved yet"}
+	ErrAlreadyClaimed    = &Error{Code: "already_claimed", Message: "Already claimed"}
+	ErrNoWinnings        = &Error{Code: "no_winnings", Message: "No winnings to claim"}
+	ErrMarketSettled     = &Error{Code: "market_settled", Message: "Market is already resolved or cancelled"}
+	ErrInvalidOutcome    = &Error{Code: "invalid_outcome", Message: `outcome must be "Yes" or "No"`}
+)
+
+// IsNotFound reports whether err means the market, user or position does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrMarketNotFound) || errors.Is(err, ErrUserNotFound) || errors.Is(err, ErrPositionNotFound)
+}
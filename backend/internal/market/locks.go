@@ -0,0 +1,53 @@
+package market
+
+import (
+	"strconv"
+	"sync"
+)
+
+// locks hands out one mutex per key, so changes to the same user or market
+// are applied one at a time while unrelated ones run in parallel. It only
+// serializes callers within this process.
+type locks struct {
+	mu    sync.Mutex
+	byKey map[string]*keyLock
+}
+
+// keyLock is the mutex for one key and the number of callers holding or
+// waiting for it; the entry is dropped once nobody is
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock acquires the mutex for key and returns the function that releases it
+func (l *locks) lock(key string) func() {
+	l.mu.Lock()
+	if l.byKey == nil {
+		l.byKey = make(map[string]*keyLock)
+	}
+	k, ok := l.byKey[key]
+	if !ok {
+		k = &keyLock{}
+		l.byKey[key] = k
+	}
+	k.refs++
+	l.mu.Unlock()
+
+	k.Lock()
+	return func() {
+		k.Unlock()
+
+		l.mu.Lock()
+		k.refs--
+		if k.refs == 0 {
+			delete(l.byKey, key)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// Lock keys. A caller that needs both takes the user's lock first.
+func userKey(user string) string { return "user:" + user }
+
+func marketKey(id int) string { return "market:" + strconv.Itoa(id) }
@@ -0,0 +1,34 @@
+package market
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLocks(t *testing.T) {
+	var l locks
+	var wg sync.WaitGroup
+	counts := map[string]*int{userKey("alice"): new(int), marketKey(1): new(int), marketKey(2): new(int)}
+
+	// The counts are only safe to update if each key is held by one caller at a time
+	for i := 0; i < 50; i++ {
+		for key := range counts {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				defer l.lock(key)()
+				*counts[key]++
+			}(key)
+		}
+	}
+	wg.Wait()
+
+	for key, n := range counts {
+		if *n != 50 {
+			t.Errorf("%s was incremented %d times, want 50", key, *n)
+		}
+	}
+	if len(l.byKey) != 0 {
+		t.Errorf("%d locks remain after every holder released them, want 0", len(l.byKey))
+	}
+}
@@ -0,0 +1,499 @@
+// Package market applies the trading rules of the prediction market: placing
+// bets, paying out claims and creating, resolving and cancelling markets. The
+// REST, GraphQL and gRPC APIs all go through it rather than changing storage
+// themselves.
+package market
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/events"
+	"github.com/linera-prediction-market/backend/internal/history"
+	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/storage"
+	"github.com/linera-prediction-market/backend/internal/validation"
+)
+
+// Store defines the storage operations the service needs
+type Store interface {
+	validation.Store
+	ListMarkets(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error)
+	GetExpiredMarkets(ctx context.Context) ([]*models.Market, error)
+	GetMarket(ctx context.Context, id int) (*models.Market, error)
+	SaveMarket(ctx context.Context, market *models.Market) error
+	UpdateMarket(ctx context.Context, market *models.Market) error
+	SetMarketTags(ctx context.Context, marketID int, tags []string) error
+	GetPosition(ctx context.Context, marketID int) (*models.UserPosition, error)
+	SavePosition(ctx context.Context, position *models.UserPosition) error
+	GetBalance(ctx context.Context) (float64, error)
+	UpdateBalance(ctx context.Context, amount float64) error
+	SavePriceSnapshot(ctx context.Context, snapshot *models.PriceSnapshot) error
+	SaveTrade(ctx context.Context, trade *models.Trade) error
+}
+
+// LineraClient defines the Linera contract operations changes are synced to
+type LineraClient interface {
+	IsEnabled() bool
+	PlaceBet(ctx context.Context, marketID int, outcome string, amount int) error
+	ResolveMarket(ctx context.Context, marketID int, outcome string) error
+	CreateMarket(ctx context.Context, question string, category string, endTime time.Time) error
+}
+
+// EventPublisher defines the event bus market changes are announced on
+type EventPublisher interface {
+	Publish(event models.Event)
+}
+
+// Service applies market rules on top of storage
+type Service struct {
+	store     Store
+	linera    LineraClient
+	events    EventPublisher
+	validator *validation.Validator
+	betLimits validation.BetLimits
+	locks     locks          // serialize read-check-write changes per user and market
+	pending   sync.WaitGroup // Linera syncs still running
+}
+
+// New creates a service with the default validation rules and bet limits
+func New(s Store, l LineraClient, e EventPublisher) *Service {
+	return &Service{
+		store:     s,
+		linera:    l,
+		events:    e,
+		validator: validation.New(s, validation.DefaultRules),
+		betLimits: validation.DefaultBetLimits,
+	}
+}
+
+// SetBetLimits replaces the size and risk limits applied to new bets
+func (s *Service) SetBetLimits(limits validation.BetLimits) {
+	s.betLimits = limits
+}
+
+// CreateRequest describes a new market
+type CreateRequest struct {
+	Question         string
+	Category         string
+	Tags             []string
+	EndTime          time.Time
+	ResolutionSource string
+
+	// Initial liquidity of each pool; shares are minted at 1000 per token
+	YesPool float64
+	NoPool  float64
+	// When the market was created; zero means now
+	CreatedAt time.Time
+}
+
+// pageSize is how many markets Expired reads from storage at a time
+const pageSize = 100
+
+// List returns a page of markets, locking expired ones first so their status is current
+func (s *Service) List(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error) {
+	if err := s.LockExpired(ctx); err != nil {
+		return nil, err
+	}
+
+	page, err := s.store.ListMarkets(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list markets: %w", err)
+	}
+	return page, nil
+}
+
+// Get returns a market, or ErrMarketNotFound
+func (s *Service) Get(ctx context.Context, id int) (*models.Market, error) {
+	market, err := s.store.GetMarket(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch market: %w", err)
+	}
+	if market == nil {
+		return nil, ErrMarketNotFound
+	}
+	return market, nil
+}
+
+// LockExpired locks active markets whose end time has passed
+func (s *Service) LockExpired(ctx context.Context) error {
+	expired, err := s.store.GetExpiredMarkets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch expired markets: %w", err)
+	}
+
+	for _, market := range expired {
+		s.lockMarket(ctx, market.ID)
+	}
+	return nil
+}
+
+// lockMarket locks an active market, re-reading it under its lock so a bet or
+// settlement made since it was listed is not overwritten
+func (s *Service) lockMarket(ctx context.Context, id int) {
+	defer s.locks.lock(marketKey(id))()
+
+	market, err := s.store.GetMarket(ctx, id)
+	if err != nil || market == nil || market.Status != models.StatusActive {
+		return
+	}
+
+	market.Status = models.StatusLocked
+	if err := s.store.UpdateMarket(ctx, market); err == nil {
+		s.events.Publish(events.MarketEvent(models.EventMarketStatus, market))
+	}
+}
+
+// PlaceBet buys shares of one outcome for user, moving the pools and
+// recording the trade and a price snapshot
+func (s *Service) PlaceBet(ctx context.Context, user string, req models.BetRequest) (*models.BetResponse, error) {
+	if err := checkUser(user); err != nil {
+		return nil, err
+	}
+
+	if err := s.betLimits.CheckRequest(req); err != nil {
+		return nil, err
+	}
+
+	// The balance, exposure and pool checks hold only until the bet is written
+	defer s.locks.lock(userKey(user))()
+	defer s.locks.lock(marketKey(req.MarketID))()
+
+	market, err := s.Get(ctx, req.MarketID)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := s.store.GetPosition(ctx, req.MarketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch position: %w", err)
+	}
+
+	balance, err := s.store.GetBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+
+	if err := s.betLimits.CheckBet(req, market, position, balance, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if position == nil {
+		position = &models.UserPosition{
+			MarketID: req.MarketID,
+		}
+	}
+
+	// Record the trade at the odds the bet was placed against
+	odds := models.CalculateOdds(market)
+	trade := &models.Trade{
+		User:     user,
+		MarketID: req.MarketID,
+		Outcome:  req.Outcome,
+		Amount:   req.Amount,
+		Price:    odds.No,
+	}
+
+	// Update market pools, shares and the position
+	if req.Outcome == models.OutcomeYes {
+		shares := storage.CalculateShares(market.YesPool, market.TotalYesShares, req.Amount)
+		market.YesPool += req.Amount
+		market.TotalYesShares += shares
+		trade.Shares = shares
+		trade.Price = odds.Yes
+
+		position.YesShares += shares
+		position.YesAmount += req.Amount
+	} else {
+		shares := storage.CalculateShares(market.NoPool, market.TotalNoShares, req.Amount)
+		market.NoPool += req.Amount
+		market.TotalNoShares += shares
+		trade.Shares = shares
+
+		position.NoShares += shares
+		position.NoAmount += req.Amount
+	}
+
+	// The writes below are not transactional, so once they start a
+	// cancelled request must not stop them halfway
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	if err := s.store.SavePosition(ctx, position); err != nil {
+		return nil, fmt.Errorf("failed to save position: %w", err)
+	}
+
+	if err := s.store.UpdateMarket(ctx, market); err != nil {
+		return nil, fmt.Errorf("failed to update market: %w", err)
+	}
+
+	if err := s.store.UpdateBalance(ctx, -req.Amount); err != nil {
+		return nil, fmt.Errorf("failed to update balance: %w", err)
+	}
+
+	trade.CreatedAt = time.Now().UTC()
+	if err := s.store.SaveTrade(ctx, trade); err != nil {
+		log.Printf("❌ Failed to record trade on market #%d: %v", market.ID, err)
+	}
+
+	if err := s.store.SavePriceSnapshot(ctx, history.Snapshot(market, req.Amount)); err != nil {
+		log.Printf("⚠️  Failed to record price snapshot for market #%d: %v", market.ID, err)
+	}
+
+	s.events.Publish(events.MarketEvent(models.EventBetPlaced, market))
+
+	outcome := string(req.Outcome)
+	s.sync(ctx, fmt.Sprintf("bet: %s %.0f on market #%d", outcome, req.Amount, req.MarketID), func(ctx context.Context) error {
+		return s.linera.PlaceBet(ctx, req.MarketID, outcome, int(req.Amount))
+	})
+
+	balance, _ = s.store.GetBalance(ctx)
+	s.events.Publish(events.BalanceEvent(req.MarketID, balance))
+
+	return &models.BetResponse{
+		Success: true,
+		Market:  market,
+		Balance: balance,
+	}, nil
+}
+
+// Claim pays out user's position in a settled market: the winnings of a
+// resolved market, or the stake of a cancelled one
+func (s *Service) Claim(ctx context.Context, user string, marketID int) (*models.ClaimResponse, error) {
+	if err := checkUser(user); err != nil {
+		return nil, err
+	}
+
+	// A position is only marked claimed once it has been read as unclaimed
+	defer s.locks.lock(userKey(user))()
+	defer s.locks.lock(marketKey(marketID))()
+
+	market, err := s.Get(ctx, marketID)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := s.store.GetPosition(ctx, marketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch position: %w", err)
+	}
+	if position == nil {
+		return nil, ErrPositionNotFound
+	}
+
+	var payout float64
+	switch market.Status {
+	case models.StatusResolved:
+		payout = storage.CalculatePayout(market, position)
+	case models.StatusCancelled:
+		payout = position.YesAmount + position.NoAmount
+	default:
+		return nil, ErrMarketNotResolved
+	}
+
+	if position.Claimed {
+		return nil, ErrAlreadyClaimed
+	}
+
+	if payout == 0 {
+		return nil, ErrNoWinnings
+	}
+
+	// Mark the claim and pay it out together even if the request is cancelled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	position.Claimed = true
+	if err := s.store.SavePosition(ctx, position); err != nil {
+		return nil, fmt.Errorf("failed to save position: %w", err)
+	}
+
+	if err := s.store.UpdateBalance(ctx, payout); err != nil {
+		return nil, fmt.Errorf("failed to update balance: %w", err)
+	}
+
+	balance, _ := s.store.GetBalance(ctx)
+	s.events.Publish(events.BalanceEvent(marketID, balance))
+
+	return &models.ClaimResponse{
+		Success: true,
+		Payout:  payout,
+		Balance: balance,
+	}, nil
+}
+
+// Create validates and saves a new active market
+func (s *Service) Create(ctx context.Context, req CreateRequest) (*models.Market, error) {
+	market := &models.Market{
+		Question:         req.Question,
+		Category:         req.Category,
+		Status:           models.StatusActive,
+		EndTime:          req.EndTime,
+		YesPool:          req.YesPool,
+		NoPool:           req.NoPool,
+		TotalYesShares:   req.YesPool * 1000,
+		TotalNoShares:    req.NoPool * 1000,
+		ResolutionSource: req.ResolutionSource,
+		Tags:             req.Tags,
+		CreatedAt:        req.CreatedAt,
+	}
+	if market.CreatedAt.IsZero() {
+		market.CreatedAt = time.Now()
+	}
+
+	if err := s.validator.ValidateMarket(ctx, market); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SaveMarket(ctx, market); err != nil {
+		return nil, fmt.Errorf("failed to create market: %w", err)
+	}
+
+	s.events.Publish(events.MarketEvent(models.EventMarketCreated, market))
+
+	s.sync(ctx, "market creation: "+market.Question, func(ctx context.Context) error {
+		return s.linera.CreateMarket(ctx, market.Question, market.Category, market.EndTime)
+	})
+
+	return market, nil
+}
+
+// Resolve settles a market on the winning outcome
+func (s *Service) Resolve(ctx context.Context, id int, outcome models.Outcome) (*models.Market, error) {
+	if outcome != models.OutcomeYes && outcome != models.OutcomeNo {
+		return nil, ErrInvalidOutcome
+	}
+
+	market, err := s.settle(ctx, id, models.StatusResolved, &outcome)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sync(ctx, fmt.Sprintf("market resolution: market #%d → %s", id, outcome), func(ctx context.Context) error {
+		return s.linera.ResolveMarket(ctx, id, string(outcome))
+	})
+
+	return market, nil
+}
+
+// Cancel voids a market; every position can then claim back its stake
+func (s *Service) Cancel(ctx context.Context, id int) (*models.Market, error) {
+	return s.settle(ctx, id, models.StatusCancelled, nil)
+}
+
+// SetTags normalizes tags and replaces the tags of a market with them
+func (s *Service) SetTags(ctx context.Context, id int, tags []string) (*models.Market, error) {
+	tags, errs := s.validator.NormalizeTags(tags)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	market, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SetMarketTags(ctx, id, tags); err != nil {
+		return nil, fmt.Errorf("failed to update tags: %w", err)
+	}
+
+	market.Tags = tags
+	return market, nil
+}
+
+// Expired returns the markets whose end time has passed and that still
+// await an outcome, locking any that are not locked yet
+func (s *Service) Expired(ctx context.Context) ([]*models.Market, error) {
+	if err := s.LockExpired(ctx); err != nil {
+		return nil, err
+	}
+
+	var markets []*models.Market
+	query := models.MarketQuery{Status: string(models.StatusLocked), SortBy: models.SortEndingSoon, Limit: pageSize}
+	for {
+		page, err := s.store.ListMarkets(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list locked markets: %w", err)
+		}
+		markets = append(markets, page.Markets...)
+		if len(page.Markets) < pageSize {
+			return markets, nil
+		}
+		query.After = page.Markets[len(page.Markets)-1].ID
+	}
+}
+
+// settle moves a market that is not yet resolved or cancelled to its final status
+func (s *Service) settle(ctx context.Context, id int, status models.MarketStatus, outcome *models.Outcome) (*models.Market, error) {
+	defer s.locks.lock(marketKey(id))()
+
+	market, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if market.Status == models.StatusResolved || market.Status == models.StatusCancelled {
+		return nil, ErrMarketSettled
+	}
+
+	market.Status = status
+	market.WinningOutcome = outcome
+
+	if err := s.store.UpdateMarket(ctx, market); err != nil {
+		return nil, fmt.Errorf("failed to update market: %w", err)
+	}
+
+	s.events.Publish(events.MarketEvent(models.EventMarketStatus, market))
+	return market, nil
+}
+
+// sync runs a Linera contract call in the background, best-effort. The call
+// outlives the request that made the change, so it keeps ctx's values but
+// not its cancellation.
+func (s *Service) sync(ctx context.Context, what string, call func(ctx context.Context) error) {
+	if !s.linera.IsEnabled() {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		if err := call(ctx); err != nil {
+			log.Printf("⚠️  Failed to sync %s to Linera: %v", what, err)
+		} else {
+			log.Printf("✅ Synced %s to Linera", what)
+		}
+	}()
+}
+
+// Wait blocks until the Linera syncs started so far have finished, or ctx
+// is done. Call it once no more requests can reach the service.
+func (s *Service) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkUser refuses users other than the single demo account storage tracks
+func checkUser(user string) error {
+	if user != models.DefaultUser {
+		return ErrUserNotFound
+	}
+	return nil
+}
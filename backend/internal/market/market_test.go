@@ -0,0 +1,752 @@
+// Table-driven tests of the market service against in-memory storage. Each
+// case builds a fresh service and checks bets, claims, settlement, market
+// creation, tagging or the Linera sync.
+package market_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/events"
+	"github.com/linera-prediction-market/backend/internal/market"
+	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/storage"
+	"github.com/linera-prediction-market/backend/internal/validation"
+)
+
+func TestService(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T)
+	}{
+		{"PlaceBet", testPlaceBet},
+		{"BetLimits", testBetLimits},
+		{"Claim", testClaim},
+		{"Settle", testSettle},
+		{"Create", testCreate},
+		{"Expired", testExpired},
+		{"SetTags", testSetTags},
+		{"LineraSync", testLineraSync},
+		{"ConcurrentBets", testConcurrentBets},
+		{"ConcurrentClaims", testConcurrentClaims},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.fn)
+	}
+}
+
+// fakeLinera records the contract calls the service syncs
+type fakeLinera struct {
+	enabled bool
+	calls   chan string
+}
+
+func (l *fakeLinera) IsEnabled() bool { return l.enabled }
+
+func (l *fakeLinera) PlaceBet(ctx context.Context, marketID int, outcome string, amount int) error {
+	l.calls <- fmt.Sprintf("bet %d %s %d", marketID, outcome, amount)
+	return nil
+}
+
+func (l *fakeLinera) ResolveMarket(ctx context.Context, marketID int, outcome string) error {
+	l.calls <- fmt.Sprintf("resolve %d %s", marketID, outcome)
+	return nil
+}
+
+func (l *fakeLinera) CreateMarket(ctx context.Context, question string, category string, endTime time.Time) error {
+	l.calls <- fmt.Sprintf("create %s %s", question, category)
+	return nil
+}
+
+type env struct {
+	store   *storage.Storage
+	linera  *fakeLinera
+	service *market.Service
+}
+
+func newEnv() *env {
+	store := storage.New()
+	linera := &fakeLinera{calls: make(chan string, 16)}
+	return &env{
+		store:   store,
+		linera:  linera,
+		service: market.New(store, linera, events.NewBus(store)),
+	}
+}
+
+// slowStore pauses after the reads a bet or claim is checked against, so
+// concurrent requests that are not serialized would all pass the checks
+// before any of them writes
+type slowStore struct {
+	*storage.Storage
+}
+
+func (s slowStore) GetPosition(ctx context.Context, marketID int) (*models.UserPosition, error) {
+	defer time.Sleep(time.Millisecond)
+	return s.Storage.GetPosition(ctx, marketID)
+}
+
+func (s slowStore) GetBalance(ctx context.Context) (float64, error) {
+	defer time.Sleep(time.Millisecond)
+	return s.Storage.GetBalance(ctx)
+}
+
+func newSlowEnv() *env {
+	e := newEnv()
+	e.service = market.New(slowStore{e.store}, e.linera, events.NewBus(e.store))
+	return e
+}
+
+// saveMarket stores a market with 100 tokens in each pool directly, bypassing
+// the validation Create applies so tests can use past end times
+func (e *env) saveMarket(t *testing.T, status models.MarketStatus, endTime time.Time) *models.Market {
+	t.Helper()
+	m := &models.Market{
+		Question:       "Will the test market settle?",
+		Category:       "Crypto",
+		Status:         status,
+		EndTime:        endTime,
+		YesPool:        100,
+		NoPool:         100,
+		TotalYesShares: 100 * storage.InitialShareMultiplier,
+		TotalNoShares:  100 * storage.InitialShareMultiplier,
+	}
+	if err := e.store.SaveMarket(ctx, m); err != nil {
+		t.Fatalf("SaveMarket: %v", err)
+	}
+	return m
+}
+
+func (e *env) balance(t *testing.T) float64 {
+	t.Helper()
+	balance, err := e.store.GetBalance(ctx)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	return balance
+}
+
+// errorCode returns the stable code of a service error, "" for nil
+func errorCode(err error) string {
+	var marketErr *market.Error
+	var betErr *validation.BetError
+	var fieldErrs validation.Errors
+
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &marketErr):
+		return marketErr.Code
+	case errors.As(err, &betErr):
+		return betErr.Code
+	case errors.As(err, &fieldErrs):
+		return "validation_failed"
+	default:
+		return "unexpected error: " + err.Error()
+	}
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+var (
+	ctx    = context.Background()
+	future = time.Now().Add(24 * time.Hour)
+	past   = time.Now().Add(-time.Hour)
+)
+
+func testPlaceBet(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     string
+		status   models.MarketStatus
+		missing  bool
+		ended    bool    // end time has passed but the market is still Active
+		balance  float64 // 0 keeps the default balance
+		outcome  models.Outcome
+		amount   float64
+		wantCode string
+	}{
+		{name: "yes", outcome: models.OutcomeYes, amount: 50},
+		{name: "no", outcome: models.OutcomeNo, amount: 50},
+		{name: "whole balance", balance: 200, outcome: models.OutcomeYes, amount: 200},
+		{name: "unknown user", user: "mallory", outcome: models.OutcomeYes, amount: 50, wantCode: "user_not_found"},
+		{name: "missing market", missing: true, outcome: models.OutcomeYes, amount: 50, wantCode: "market_not_found"},
+		{name: "invalid outcome", outcome: "Maybe", amount: 50, wantCode: validation.CodeInvalidOutcome},
+		{name: "zero amount", outcome: models.OutcomeYes, amount: 0, wantCode: validation.CodeInvalidAmount},
+		{name: "too small", outcome: models.OutcomeYes, amount: 0.5, wantCode: validation.CodeBetTooSmall},
+		{name: "too large", outcome: models.OutcomeYes, amount: 10001, wantCode: validation.CodeBetTooLarge},
+		{name: "insufficient balance", balance: 40, outcome: models.OutcomeNo, amount: 50, wantCode: validation.CodeInsufficientBalance},
+		{name: "locked market", status: models.StatusLocked, outcome: models.OutcomeYes, amount: 50, wantCode: validation.CodeMarketNotActive},
+		{name: "cancelled market", status: models.StatusCancelled, outcome: models.OutcomeYes, amount: 50, wantCode: validation.CodeMarketNotActive},
+		{name: "ended market not yet locked", ended: true, outcome: models.OutcomeYes, amount: 50, wantCode: validation.CodeMarketNotActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv()
+			if tt.user == "" {
+				tt.user = models.DefaultUser
+			}
+			if tt.status == "" {
+				tt.status = models.StatusActive
+			}
+			endTime := future
+			if tt.ended {
+				endTime = past
+			}
+			m := e.saveMarket(t, tt.status, endTime)
+			if tt.missing {
+				m.ID = 999
+			}
+			if tt.balance > 0 {
+				if err := e.store.UpdateBalance(ctx, tt.balance-storage.DefaultBalance); err != nil {
+					t.Fatalf("UpdateBalance: %v", err)
+				}
+			}
+			before := e.balance(t)
+
+			resp, err := e.service.PlaceBet(ctx, tt.user, models.BetRequest{MarketID: m.ID, Outcome: tt.outcome, Amount: tt.amount})
+			if got := errorCode(err); got != tt.wantCode {
+				t.Fatalf("PlaceBet error = %q, want %q", got, tt.wantCode)
+			}
+
+			if tt.wantCode != "" {
+				if got := e.balance(t); got != before {
+					t.Errorf("balance = %v after a refused bet, want %v", got, before)
+				}
+				trades, _ := e.store.GetTrades(ctx, models.TradeQuery{Limit: 10})
+				if len(trades) != 0 {
+					t.Errorf("refused bet recorded %d trades", len(trades))
+				}
+				return
+			}
+
+			// 100 tokens back 100,000 shares, so each token buys 1,000 shares
+			wantShares := tt.amount * storage.InitialShareMultiplier
+			wantBalance := before - tt.amount
+			if resp.Balance != wantBalance || e.balance(t) != wantBalance {
+				t.Errorf("balance = %v (stored %v), want %v", resp.Balance, e.balance(t), wantBalance)
+			}
+
+			stored, _ := e.store.GetMarket(ctx, m.ID)
+			position, _ := e.store.GetPosition(ctx, m.ID)
+			if position == nil {
+				t.Fatalf("no position after bet")
+			}
+			pool, shares, positionShares, staked := stored.YesPool, stored.TotalYesShares, position.YesShares, position.YesAmount
+			if tt.outcome == models.OutcomeNo {
+				pool, shares, positionShares, staked = stored.NoPool, stored.TotalNoShares, position.NoShares, position.NoAmount
+			}
+			if pool != 100+tt.amount || !approx(shares, 100*storage.InitialShareMultiplier+wantShares) {
+				t.Errorf("pool = %v with %v shares, want %v with %v", pool, shares, 100+tt.amount, 100*storage.InitialShareMultiplier+wantShares)
+			}
+			if !approx(positionShares, wantShares) || staked != tt.amount {
+				t.Errorf("position holds %v shares for %v tokens, want %v for %v", positionShares, staked, wantShares, tt.amount)
+			}
+
+			trades, _ := e.store.GetTrades(ctx, models.TradeQuery{MarketID: m.ID, Limit: 10})
+			if len(trades) != 1 {
+				t.Fatalf("got %d trades, want 1", len(trades))
+			}
+			trade := trades[0]
+			if trade.User != tt.user || trade.Outcome != tt.outcome || trade.Amount != tt.amount || !approx(trade.Shares, wantShares) || trade.Price != 0.5 {
+				t.Errorf("unexpected trade: %+v", trade)
+			}
+		})
+	}
+}
+
+func testBetLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   validation.BetLimits
+		staked   float64 // placed on Yes before the limits apply
+		pools    [2]float64
+		outcome  models.Outcome
+		amount   float64
+		wantCode string
+	}{
+		{name: "exposure at the cap", limits: validation.BetLimits{MaxExposure: 100}, staked: 60, outcome: models.OutcomeNo, amount: 40},
+		{name: "exposure one over", limits: validation.BetLimits{MaxExposure: 100}, staked: 60, outcome: models.OutcomeNo, amount: 41, wantCode: validation.CodeExposureLimit},
+		{name: "first bet at the cap", limits: validation.BetLimits{MaxExposure: 100}, outcome: models.OutcomeYes, amount: 100},
+		{name: "first bet one over", limits: validation.BetLimits{MaxExposure: 100}, outcome: models.OutcomeYes, amount: 101, wantCode: validation.CodeExposureLimit},
+		{name: "imbalance at the cap", limits: validation.BetLimits{MaxPoolImbalance: 100}, pools: [2]float64{100, 100}, outcome: models.OutcomeYes, amount: 100},
+		{name: "imbalance one over", limits: validation.BetLimits{MaxPoolImbalance: 100}, pools: [2]float64{100, 100}, outcome: models.OutcomeYes, amount: 101, wantCode: validation.CodePoolImbalance},
+		{name: "imbalance over on No", limits: validation.BetLimits{MaxPoolImbalance: 100}, pools: [2]float64{100, 100}, outcome: models.OutcomeNo, amount: 101, wantCode: validation.CodePoolImbalance},
+		{name: "rebalancing beyond the cap", limits: validation.BetLimits{MaxPoolImbalance: 100}, pools: [2]float64{400, 100}, outcome: models.OutcomeNo, amount: 50},
+		{name: "widening beyond the cap", limits: validation.BetLimits{MaxPoolImbalance: 100}, pools: [2]float64{400, 100}, outcome: models.OutcomeYes, amount: 1, wantCode: validation.CodePoolImbalance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv()
+			m := e.saveMarket(t, models.StatusActive, future)
+			if tt.staked > 0 {
+				if _, err := e.service.PlaceBet(ctx, models.DefaultUser, models.BetRequest{MarketID: m.ID, Outcome: models.OutcomeYes, Amount: tt.staked}); err != nil {
+					t.Fatalf("PlaceBet: %v", err)
+				}
+			}
+			if tt.pools != [2]float64{} {
+				stored, _ := e.store.GetMarket(ctx, m.ID)
+				stored.YesPool, stored.NoPool = tt.pools[0], tt.pools[1]
+				if err := e.store.UpdateMarket(ctx, stored); err != nil {
+					t.Fatalf("UpdateMarket: %v", err)
+				}
+			}
+			e.service.SetBetLimits(tt.limits)
+
+			_, err := e.service.PlaceBet(ctx, models.DefaultUser, models.BetRequest{MarketID: m.ID, Outcome: tt.outcome, Amount: tt.amount})
+			if got := errorCode(err); got != tt.wantCode {
+				t.Errorf("PlaceBet error = %q, want %q", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+type bet struct {
+	outcome models.Outcome
+	amount  float64
+}
+
+func testClaim(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       string
+		bets       []bet
+		status     models.MarketStatus // Resolved, Cancelled or left Active
+		winner     models.Outcome
+		claimTwice bool
+		wantCode   string
+		wantPayout float64
+	}{
+		// 100 Yes tokens buy 100,000 of 200,000 Yes shares in a 300 token market
+		{name: "winner", bets: []bet{{models.OutcomeYes, 100}}, status: models.StatusResolved, winner: models.OutcomeYes, wantPayout: 150},
+		{name: "both sides", bets: []bet{{models.OutcomeYes, 100}, {models.OutcomeNo, 100}}, status: models.StatusResolved, winner: models.OutcomeNo, wantPayout: 200},
+		{name: "loser", bets: []bet{{models.OutcomeYes, 100}}, status: models.StatusResolved, winner: models.OutcomeNo, wantCode: "no_winnings"},
+		{name: "cancelled refund", bets: []bet{{models.OutcomeYes, 100}, {models.OutcomeNo, 50}}, status: models.StatusCancelled, wantPayout: 150},
+		{name: "not settled", bets: []bet{{models.OutcomeYes, 100}}, status: models.StatusActive, wantCode: "market_not_resolved"},
+		{name: "no position", status: models.StatusResolved, winner: models.OutcomeYes, wantCode: "position_not_found"},
+		{name: "claimed twice", bets: []bet{{models.OutcomeYes, 100}}, status: models.StatusResolved, winner: models.OutcomeYes, claimTwice: true, wantCode: "already_claimed"},
+		{name: "unknown user", user: "mallory", bets: []bet{{models.OutcomeYes, 100}}, status: models.StatusResolved, winner: models.OutcomeYes, wantCode: "user_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv()
+			if tt.user == "" {
+				tt.user = models.DefaultUser
+			}
+			m := e.saveMarket(t, models.StatusActive, future)
+
+			for _, b := range tt.bets {
+				if _, err := e.service.PlaceBet(ctx, models.DefaultUser, models.BetRequest{MarketID: m.ID, Outcome: b.outcome, Amount: b.amount}); err != nil {
+					t.Fatalf("PlaceBet: %v", err)
+				}
+			}
+
+			var err error
+			switch tt.status {
+			case models.StatusResolved:
+				_, err = e.service.Resolve(ctx, m.ID, tt.winner)
+			case models.StatusCancelled:
+				_, err = e.service.Cancel(ctx, m.ID)
+			}
+			if err != nil {
+				t.Fatalf("settling market: %v", err)
+			}
+
+			if tt.claimTwice {
+				if _, err := e.service.Claim(ctx, tt.user, m.ID); err != nil {
+					t.Fatalf("first Claim: %v", err)
+				}
+			}
+
+			before := e.balance(t)
+			resp, err := e.service.Claim(ctx, tt.user, m.ID)
+			if got := errorCode(err); got != tt.wantCode {
+				t.Fatalf("Claim error = %q, want %q", got, tt.wantCode)
+			}
+			if tt.wantCode != "" {
+				if got := e.balance(t); got != before {
+					t.Errorf("balance = %v after a refused claim, want %v", got, before)
+				}
+				return
+			}
+
+			if resp.Payout != tt.wantPayout {
+				t.Errorf("payout = %v, want %v", resp.Payout, tt.wantPayout)
+			}
+			if resp.Balance != before+tt.wantPayout || e.balance(t) != before+tt.wantPayout {
+				t.Errorf("balance = %v (stored %v), want %v", resp.Balance, e.balance(t), before+tt.wantPayout)
+			}
+			if position, _ := e.store.GetPosition(ctx, m.ID); position == nil || !position.Claimed {
+				t.Errorf("position not marked claimed: %+v", position)
+			}
+		})
+	}
+}
+
+func testSettle(t *testing.T) {
+	resolve := func(outcome models.Outcome) func(e *env, id int) (*models.Market, error) {
+		return func(e *env, id int) (*models.Market, error) { return e.service.Resolve(ctx, id, outcome) }
+	}
+	cancel := func(e *env, id int) (*models.Market, error) { return e.service.Cancel(ctx, id) }
+
+	yes, no := models.OutcomeYes, models.OutcomeNo
+	tests := []struct {
+		name        string
+		status      models.MarketStatus
+		missing     bool
+		settle      func(e *env, id int) (*models.Market, error)
+		wantCode    string
+		wantStatus  models.MarketStatus
+		wantOutcome *models.Outcome
+	}{
+		{name: "resolve active", status: models.StatusActive, settle: resolve(yes), wantStatus: models.StatusResolved, wantOutcome: &yes},
+		{name: "resolve locked", status: models.StatusLocked, settle: resolve(no), wantStatus: models.StatusResolved, wantOutcome: &no},
+		{name: "resolve invalid outcome", status: models.StatusActive, settle: resolve("Maybe"), wantCode: "invalid_outcome"},
+		{name: "resolve resolved", status: models.StatusResolved, settle: resolve(yes), wantCode: "market_settled"},
+		{name: "resolve cancelled", status: models.StatusCancelled, settle: resolve(yes), wantCode: "market_settled"},
+		{name: "resolve missing", missing: true, settle: resolve(yes), wantCode: "market_not_found"},
+		{name: "cancel active", status: models.StatusActive, settle: cancel, wantStatus: models.StatusCancelled},
+		{name: "cancel locked", status: models.StatusLocked, settle: cancel, wantStatus: models.StatusCancelled},
+		{name: "cancel resolved", status: models.StatusResolved, settle: cancel, wantCode: "market_settled"},
+		{name: "cancel missing", missing: true, settle: cancel, wantCode: "market_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv()
+			id := 999
+			if !tt.missing {
+				id = e.saveMarket(t, tt.status, future).ID
+			}
+
+			m, err := tt.settle(e, id)
+			if got := errorCode(err); got != tt.wantCode {
+				t.Fatalf("error = %q, want %q", got, tt.wantCode)
+			}
+			if tt.wantCode != "" {
+				if stored, _ := e.store.GetMarket(ctx, id); stored != nil && stored.Status != tt.status {
+					t.Errorf("refused settlement changed status to %s", stored.Status)
+				}
+				return
+			}
+
+			stored, _ := e.store.GetMarket(ctx, id)
+			for _, got := range []*models.Market{m, stored} {
+				if got.Status != tt.wantStatus || !reflect.DeepEqual(got.WinningOutcome, tt.wantOutcome) {
+					t.Errorf("market is %s with outcome %v, want %s with %v", got.Status, got.WinningOutcome, tt.wantStatus, tt.wantOutcome)
+				}
+			}
+		})
+	}
+}
+
+func testCreate(t *testing.T) {
+	valid := market.CreateRequest{
+		Question:         "Will the service layer ship this week?",
+		Category:         "Binary",
+		EndTime:          future,
+		ResolutionSource: "https://example.com/results",
+	}
+	with := func(change func(r *market.CreateRequest)) market.CreateRequest {
+		r := valid
+		change(&r)
+		return r
+	}
+	created := time.Date(2030, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		req       market.CreateRequest
+		wantCode  string
+		wantField string
+	}{
+		{name: "valid", req: valid},
+		{name: "initial liquidity", req: with(func(r *market.CreateRequest) { r.YesPool, r.NoPool = 500, 300 })},
+		{name: "created at", req: with(func(r *market.CreateRequest) { r.CreatedAt = created })},
+		{name: "short question", req: with(func(r *market.CreateRequest) { r.Question = "Rain?" }), wantCode: "validation_failed", wantField: "question"},
+		{name: "unknown category", req: with(func(r *market.CreateRequest) { r.Category = "Weather" }), wantCode: "validation_failed", wantField: "category"},
+		{name: "no end time", req: with(func(r *market.CreateRequest) { r.EndTime = time.Time{} }), wantCode: "validation_failed", wantField: "endTime"},
+		{name: "ends too soon", req: with(func(r *market.CreateRequest) { r.EndTime = time.Now().Add(time.Minute) }), wantCode: "validation_failed", wantField: "endTime"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv()
+
+			m, err := e.service.Create(ctx, tt.req)
+			if got := errorCode(err); got != tt.wantCode {
+				t.Fatalf("Create error = %q, want %q", got, tt.wantCode)
+			}
+			if tt.wantCode != "" {
+				var fieldErrs validation.Errors
+				if errors.As(err, &fieldErrs) && fieldErrs[0].Field != tt.wantField {
+					t.Errorf("error on field %q, want %q", fieldErrs[0].Field, tt.wantField)
+				}
+				if markets, _ := e.store.GetMarkets(ctx); len(markets) != 0 {
+					t.Errorf("refused market was saved")
+				}
+				return
+			}
+
+			stored, _ := e.store.GetMarket(ctx, m.ID)
+			if stored == nil || stored.Question != tt.req.Question || stored.Status != models.StatusActive {
+				t.Fatalf("unexpected stored market: %+v", stored)
+			}
+			if stored.YesPool != tt.req.YesPool || stored.NoPool != tt.req.NoPool ||
+				stored.TotalYesShares != tt.req.YesPool*storage.InitialShareMultiplier ||
+				stored.TotalNoShares != tt.req.NoPool*storage.InitialShareMultiplier {
+				t.Errorf("pools %v/%v with %v/%v shares, want %v/%v at 1000 shares per token",
+					stored.YesPool, stored.NoPool, stored.TotalYesShares, stored.TotalNoShares, tt.req.YesPool, tt.req.NoPool)
+			}
+			if tt.req.CreatedAt.IsZero() && stored.CreatedAt.IsZero() || !tt.req.CreatedAt.IsZero() && !stored.CreatedAt.Equal(tt.req.CreatedAt) {
+				t.Errorf("CreatedAt = %v, want %v (now when zero)", stored.CreatedAt, tt.req.CreatedAt)
+			}
+		})
+	}
+}
+
+func testExpired(t *testing.T) {
+	e := newEnv()
+	expired := e.saveMarket(t, models.StatusActive, past.Add(-time.Hour))
+	locked := e.saveMarket(t, models.StatusLocked, past)
+	e.saveMarket(t, models.StatusActive, future)
+	e.saveMarket(t, models.StatusResolved, past)
+	e.saveMarket(t, models.StatusCancelled, past)
+
+	markets, err := e.service.Expired(ctx)
+	if err != nil {
+		t.Fatalf("Expired: %v", err)
+	}
+
+	var ids []int
+	for _, m := range markets {
+		ids = append(ids, m.ID)
+	}
+	if want := []int{expired.ID, locked.ID}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expired returned %v, want %v", ids, want)
+	}
+	if stored, _ := e.store.GetMarket(ctx, expired.ID); stored.Status != models.StatusLocked {
+		t.Errorf("expired market is %s, want Locked", stored.Status)
+	}
+}
+
+func testSetTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		missing  bool
+		tags     []string
+		want     []string
+		wantCode string
+	}{
+		{name: "normalized", tags: []string{" Bitcoin ", "bitcoin", "ETF"}, want: []string{"bitcoin", "etf"}},
+		{name: "cleared", tags: nil, want: nil},
+		{name: "invalid tag", tags: []string{"not a tag!"}, wantCode: "validation_failed"},
+		{name: "missing market", missing: true, tags: []string{"btc"}, wantCode: "market_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv()
+			id := 999
+			if !tt.missing {
+				id = e.saveMarket(t, models.StatusActive, future).ID
+			}
+
+			m, err := e.service.SetTags(ctx, id, tt.tags)
+			if got := errorCode(err); got != tt.wantCode {
+				t.Fatalf("SetTags error = %q, want %q", got, tt.wantCode)
+			}
+			if tt.wantCode != "" {
+				return
+			}
+
+			stored, _ := e.store.GetMarket(ctx, id)
+			if len(m.Tags) != len(tt.want) || len(tt.want) > 0 && !reflect.DeepEqual(stored.Tags, tt.want) {
+				t.Errorf("tags = %v (stored %v), want %v", m.Tags, stored.Tags, tt.want)
+			}
+		})
+	}
+}
+
+func testLineraSync(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		call    func(e *env, id int) error
+		want    string
+	}{
+		{
+			name:    "bet",
+			enabled: true,
+			call: func(e *env, id int) error {
+				_, err := e.service.PlaceBet(ctx, models.DefaultUser, models.BetRequest{MarketID: id, Outcome: models.OutcomeNo, Amount: 25})
+				return err
+			},
+			want: "bet 1 No 25",
+		},
+		{
+			name:    "resolution",
+			enabled: true,
+			call: func(e *env, id int) error {
+				_, err := e.service.Resolve(ctx, id, models.OutcomeYes)
+				return err
+			},
+			want: "resolve 1 Yes",
+		},
+		{
+			name:    "creation",
+			enabled: true,
+			call: func(e *env, id int) error {
+				_, err := e.service.Create(ctx, market.CreateRequest{
+					Question:         "Will the sync reach the chain?",
+					Category:         "Crypto",
+					EndTime:          future,
+					ResolutionSource: "https://example.com/results",
+				})
+				return err
+			},
+			want: "create Will the sync reach the chain? Crypto",
+		},
+		{
+			name: "disabled",
+			call: func(e *env, id int) error {
+				_, err := e.service.Resolve(ctx, id, models.OutcomeYes)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv()
+			e.linera.enabled = tt.enabled
+			m := e.saveMarket(t, models.StatusActive, future)
+
+			if err := tt.call(e, m.ID); err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+
+			if tt.want == "" {
+				select {
+				case got := <-e.linera.calls:
+					t.Errorf("synced %q while Linera is disabled", got)
+				case <-time.After(50 * time.Millisecond):
+				}
+				return
+			}
+
+			select {
+			case got := <-e.linera.calls:
+				if got != tt.want {
+					t.Errorf("synced %q, want %q", got, tt.want)
+				}
+			case <-time.After(time.Second):
+				t.Errorf("nothing synced, want %q", tt.want)
+			}
+		})
+	}
+}
+
+// concurrently runs fn n times at once and returns the error code of each call
+func concurrently(n int, fn func() error) []string {
+	codes := make([]string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes[i] = errorCode(fn())
+		}(i)
+	}
+	wg.Wait()
+	return codes
+}
+
+func countCodes(codes []string) map[string]int {
+	counts := make(map[string]int)
+	for _, c := range codes {
+		counts[c]++
+	}
+	return counts
+}
+
+func testConcurrentBets(t *testing.T) {
+	// Ten simultaneous Yes bets of 20 against a limit that leaves room for five
+	tests := []struct {
+		name     string
+		balance  float64 // 0 keeps the default balance
+		limits   validation.BetLimits
+		wantCode string
+	}{
+		{name: "balance", balance: 100, wantCode: validation.CodeInsufficientBalance},
+		{name: "exposure", limits: validation.BetLimits{MaxExposure: 100}, wantCode: validation.CodeExposureLimit},
+		{name: "pool imbalance", limits: validation.BetLimits{MaxPoolImbalance: 100}, wantCode: validation.CodePoolImbalance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newSlowEnv()
+			e.service.SetBetLimits(tt.limits)
+			m := e.saveMarket(t, models.StatusActive, future)
+			if tt.balance > 0 {
+				if err := e.store.UpdateBalance(ctx, tt.balance-storage.DefaultBalance); err != nil {
+					t.Fatalf("UpdateBalance: %v", err)
+				}
+			}
+			before := e.balance(t)
+
+			codes := concurrently(10, func() error {
+				_, err := e.service.PlaceBet(ctx, models.DefaultUser, models.BetRequest{MarketID: m.ID, Outcome: models.OutcomeYes, Amount: 20})
+				return err
+			})
+
+			if got := countCodes(codes); got[""] != 5 || got[tt.wantCode] != 5 {
+				t.Fatalf("got %v, want 5 accepted and 5 refused with %s", got, tt.wantCode)
+			}
+			if got := e.balance(t); got != before-100 {
+				t.Errorf("balance = %v, want %v", got, before-100)
+			}
+			stored, _ := e.store.GetMarket(ctx, m.ID)
+			position, _ := e.store.GetPosition(ctx, m.ID)
+			if stored.YesPool != 200 || position == nil || position.YesAmount != 100 {
+				t.Errorf("Yes pool = %v with a %+v position, want 200 with 100 staked", stored.YesPool, position)
+			}
+		})
+	}
+}
+
+func testConcurrentClaims(t *testing.T) {
+	e := newSlowEnv()
+	m := e.saveMarket(t, models.StatusActive, future)
+	if _, err := e.service.PlaceBet(ctx, models.DefaultUser, models.BetRequest{MarketID: m.ID, Outcome: models.OutcomeYes, Amount: 100}); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+	if _, err := e.service.Resolve(ctx, m.ID, models.OutcomeYes); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	before := e.balance(t)
+
+	codes := concurrently(10, func() error {
+		_, err := e.service.Claim(ctx, models.DefaultUser, m.ID)
+		return err
+	})
+
+	if got := countCodes(codes); got[""] != 1 || got["already_claimed"] != 9 {
+		t.Fatalf("got %v, want 1 payout and 9 already_claimed", got)
+	}
+	if got := e.balance(t); got != before+150 {
+		t.Errorf("balance = %v, want a single payout of 150 on top of %v", got, before)
+	}
+}
@@ -19,19 +19,35 @@ const (
 )
 
 type Market struct {
-	ID              int          `json:"id"`
-	Question        string       `json:"question"`
-	Category        string       `json:"category"`
-	Status          MarketStatus `json:"status"`
-	EndTime         time.Time    `json:"endTime"`
-	YesPool         float64      `json:"yesPool"`
-	NoPool          float64      `json:"noPool"`
-	TotalYesShares  float64      `json:"totalYesShares"`
-	TotalNoShares   float64      `json:"totalNoShares"`
-	WinningOutcome  *Outcome     `json:"winningOutcome,omitempty"`
-	CreatedAt       time.Time    `json:"createdAt"`
+	ID               int          `json:"id"`
+	Question         string       `json:"question"`
+	Category         string       `json:"category"`
+	Status           MarketStatus `json:"status"`
+	EndTime          time.Time    `json:"endTime"`
+	YesPool          float64      `json:"yesPool"`
+	NoPool           float64      `json:"noPool"`
+	TotalYesShares   float64      `json:"totalYesShares"`
+	TotalNoShares    float64      `json:"totalNoShares"`
+	WinningOutcome   *Outcome     `json:"winningOutcome,omitempty"`
+	ResolutionSource string       `json:"resolutionSource,omitempty"` // http(s) URL, or "oracle:<name>" for oracle-resolved markets
+	Tags             []string     `json:"tags,omitempty"`
+	CreatedAt        time.Time    `json:"createdAt"`
 }
 
+// Category is a node in the category hierarchy. Markets refer to categories by name.
+type Category struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	ParentID  *int      `json:"parentId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// DefaultCategories are the top-level categories every store starts with
+var DefaultCategories = []string{"Crypto", "Sports", "Binary"}
+
+// DefaultUser identifies the single demo account that owns the balance and positions
+const DefaultUser = "default"
+
 type UserPosition struct {
 	MarketID  int     `json:"marketId"`
 	YesShares float64 `json:"yesShares"`
@@ -41,6 +57,63 @@ type UserPosition struct {
 	Claimed   bool    `json:"claimed"`
 }
 
+// Trade is a single bet as placed, before it is merged into a UserPosition
+type Trade struct {
+	ID        int64     `json:"id"`
+	User      string    `json:"user"`
+	MarketID  int       `json:"marketId"`
+	Outcome   Outcome   `json:"outcome"`
+	Amount    float64   `json:"amount"`
+	Shares    float64   `json:"shares"`
+	Price     float64   `json:"price"` // implied probability of Outcome before the bet
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TradeQuery selects a page of trades, newest first
+type TradeQuery struct {
+	MarketID int    // 0 for all markets
+	User     string // empty for all users
+	Before   int64  // cursor: only trades with ID < Before (0 for the first page)
+	Limit    int
+}
+
+// Market sort orders accepted by MarketQuery.SortBy
+const (
+	SortEndingSoon   = "ending-soon"
+	SortNewest       = "newest"
+	SortPopular      = "popular"
+	SortAlphabetical = "alphabetical"
+)
+
+// MarketSorts lists every supported market sort order
+var MarketSorts = []string{SortEndingSoon, SortNewest, SortPopular, SortAlphabetical}
+
+// MarketQuery selects a filtered, sorted page of markets
+type MarketQuery struct {
+	Status   string // empty for all statuses
+	Category string // empty for all categories; includes sub-categories
+	Tag      string // empty for all tags
+	SortBy   string // one of MarketSorts
+	After    int    // cursor: ID of the last market on the previous page (0 for the first page)
+	Offset   int    // rows to skip when After is 0
+	Limit    int
+}
+
+// MarketPage is a page of markets with the number of markets matching the filters
+type MarketPage struct {
+	Markets []*Market
+	Total   int
+}
+
+// MarketSearch selects markets whose question matches a free-text query, best match first
+type MarketSearch struct {
+	Query    string
+	Status   string // empty for all statuses
+	Category string // empty for all categories; includes sub-categories
+	Tag      string // empty for all tags
+	Limit    int
+}
+
 type BetRequest struct {
 	MarketID int     `json:"marketId"`
 	Outcome  Outcome `json:"outcome"`
@@ -63,7 +136,116 @@ type ResolveRequest struct {
 	Outcome Outcome `json:"outcome"`
 }
 
+// ErrorResponse is the body of every error response
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error     string      `json:"error"`             // human-readable message
+	Code      string      `json:"code"`              // stable, machine-readable code
+	Details   interface{} `json:"details,omitempty"` // e.g. the invalid fields of a validation failure
+	RequestID string      `json:"requestId,omitempty"`
+}
+
+
+type EventType string
+
+const (
+	EventMarketCreated EventType = "market.created"
+	EventBetPlaced     EventType = "bet.placed"
+	EventMarketStatus  EventType = "market.status"
+	EventBalance       EventType = "balance.changed"
+)
+
+// Odds holds the implied probability of each outcome derived from the pools
+type Odds struct {
+	Yes float64 `json:"yes"`
+	No  float64 `json:"no"`
+}
+
+// Event is a domain event published on the internal event bus
+type Event struct {
+	Seq       int64     `json:"seq"`
+	Type      EventType `json:"type"`
+	MarketID  int       `json:"marketId"`
+	Market    *Market   `json:"market,omitempty"`
+	Odds      *Odds     `json:"odds,omitempty"`
+	Balance   *float64  `json:"balance,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// CalculateOdds returns the implied Yes/No probabilities of a market
+func CalculateOdds(market *Market) Odds {
+	total := market.YesPool + market.NoPool
+	if total == 0 {
+		return Odds{Yes: 0.5, No: 0.5}
+	}
+	return Odds{
+		Yes: market.YesPool / total,
+		No:  market.NoPool / total,
+	}
+}
+
+// PriceSnapshot is a point (or downsampled bucket) in a market's Yes-probability history
+type PriceSnapshot struct {
+	MarketID   int       `json:"marketId"`
+	Timestamp  time.Time `json:"timestamp"`
+	Resolution int       `json:"resolution"` // bucket width in seconds, 0 for raw snapshots
+	Open       float64   `json:"open"`
+	High       float64   `json:"high"`
+	Low        float64   `json:"low"`
+	Close      float64   `json:"close"`
+	Volume     float64   `json:"volume"`
+	YesPool    float64   `json:"yesPool"`
+	NoPool     float64   `json:"noPool"`
+}
+
+// Candle is an OHLC bucket of the Yes probability with traded volume
+type Candle struct {
+	Time   time.Time `json:"time"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"`
+}
+
+// PositionValuation is a position together with its cost, value and P&L
+type PositionValuation struct {
+	Market        *Market       `json:"market"`
+	Position      *UserPosition `json:"position"`
+	CostBasis     float64       `json:"costBasis"`
+	MarkValue     float64       `json:"markValue"`
+	UnrealizedPnL float64       `json:"unrealizedPnl"`
+	RealizedPnL   float64       `json:"realizedPnl"`
+	Claimable     float64       `json:"claimable"`
 }
 
+// PortfolioTotals sums the valuations across every position of the account
+type PortfolioTotals struct {
+	CostBasis     float64 `json:"costBasis"`
+	MarkValue     float64 `json:"markValue"`
+	UnrealizedPnL float64 `json:"unrealizedPnl"`
+	RealizedPnL   float64 `json:"realizedPnl"`
+	Claimable     float64 `json:"claimable"`
+	Balance       float64 `json:"balance"`
+	Equity        float64 `json:"equity"` // balance plus mark value
+}
+
+type Portfolio struct {
+	Positions []*PositionValuation `json:"positions"`
+	Totals    PortfolioTotals      `json:"totals"`
+}
+
+// LeaderboardEntry holds a user's trading statistics for one period
+type LeaderboardEntry struct {
+	Rank            int       `json:"rank"`
+	User            string    `json:"user"`
+	Period          string    `json:"period"`
+	Volume          float64   `json:"volume"`
+	Trades          int       `json:"trades"`
+	ResolvedMarkets int       `json:"resolvedMarkets"`
+	Wins            int       `json:"wins"`
+	RealizedProfit  float64   `json:"realizedProfit"`
+	WinRate         float64   `json:"winRate"`
+	ROI             float64   `json:"roi"`
+	BrierScore      *float64  `json:"brierScore"` // nil until the user has a resolved market
+	RefreshedAt     time.Time `json:"refreshedAt"`
+}
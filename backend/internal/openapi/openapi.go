@@ -0,0 +1,24 @@
+// Package openapi holds the OpenAPI 3 specification of the HTTP API.
+//
+// openapi.json is the source of truth for the routes registered in
+// handlers.Register; the Go client in the client package is generated from it.
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+// Spec returns the OpenAPI document as JSON
+func Spec() []byte {
+	return spec
+}
+
+// Handler serves the OpenAPI document
+func Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(spec)
+}
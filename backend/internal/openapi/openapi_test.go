@@ -0,0 +1,11 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/linera-prediction-market/backend/internal/openapi/openapitest"
+)
+
+func TestOpenAPI(t *testing.T) {
+	openapitest.Run(t)
+}
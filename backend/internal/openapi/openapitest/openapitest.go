@@ -0,0 +1,270 @@
+// Package openapitest checks the HTTP API against its OpenAPI specification.
+//
+// Run drives every operation of a server backed by in-memory storage and
+// validates each request and response against internal/openapi/openapi.json.
+// Call it from a test:
+//
+//	func TestOpenAPI(t *testing.T) { openapitest.Run(t) }
+package openapitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+	"github.com/linera-prediction-market/backend/internal/events"
+	"github.com/linera-prediction-market/backend/internal/handlers"
+	"github.com/linera-prediction-market/backend/internal/linera"
+	"github.com/linera-prediction-market/backend/internal/market"
+	"github.com/linera-prediction-market/backend/internal/openapi"
+	"github.com/linera-prediction-market/backend/internal/storage"
+)
+
+// serverURL must match the first server of the specification
+const serverURL = "http://localhost:3001/api"
+
+type checker struct {
+	t       *testing.T
+	handler http.Handler
+	router  routers.Router
+	covered map[string]bool // "METHOD /path" of every operation exercised
+}
+
+// Run validates live handler responses against the OpenAPI specification and
+// checks that the specification documents exactly the registered routes
+func Run(t *testing.T) {
+	t.Helper()
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(openapi.Spec())
+	if err != nil {
+		t.Fatalf("failed to load OpenAPI spec: %v", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		t.Fatalf("invalid OpenAPI spec: %v", err)
+	}
+	specRouter, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("failed to route OpenAPI spec: %v", err)
+	}
+
+	store := storage.New()
+	bus := events.NewBus(store)
+	h := handlers.New(store, market.New(store, linera.NewClient("http://localhost:8080", false), bus), bus)
+	router := mux.NewRouter()
+	h.Register(router.PathPrefix("/api").Subrouter())
+
+	t.Run("Routes", func(t *testing.T) {
+		checkRoutes(t, doc, router)
+	})
+
+	c := &checker{
+		t:       t,
+		handler: handlers.RequestID(router),
+		router:  specRouter,
+		covered: make(map[string]bool),
+	}
+	t.Run("Responses", func(t *testing.T) {
+		c.t = t
+		c.exercise()
+		for path, item := range doc.Paths.Map() {
+			for method := range item.Operations() {
+				if !c.covered[method+" "+path] {
+					t.Errorf("%s %s is never exercised", method, path)
+				}
+			}
+		}
+	})
+}
+
+// checkRoutes compares the routes registered on router with the spec's paths
+func checkRoutes(t *testing.T, doc *openapi3.T, router *mux.Router) {
+	registered := make(map[string]bool)
+	router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		path := strings.TrimPrefix(tpl, "/api")
+		for _, method := range methods {
+			registered[method+" "+path] = true
+			item := doc.Paths.Value(path)
+			if item == nil || item.GetOperation(method) == nil {
+				t.Errorf("route %s %s is missing from the OpenAPI spec", method, tpl)
+			}
+		}
+		return nil
+	})
+
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			if !registered[method+" "+path] {
+				t.Errorf("OpenAPI operation %s %s has no registered route", method, path)
+			}
+		}
+	}
+}
+
+// do sends a request and validates the response against the spec. The request
+// itself is validated too unless it is deliberately invalid.
+func (c *checker) do(method, path string, body interface{}, wantStatus int, invalidRequest bool) []byte {
+	c.t.Helper()
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			c.t.Fatalf("failed to encode %s %s body: %v", method, path, err)
+		}
+	}
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(method, serverURL+path, bytes.NewReader(payload))
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		return req
+	}
+
+	req := newRequest()
+	route, pathParams, err := c.router.FindRoute(req)
+	if err != nil {
+		c.t.Errorf("%s %s is not in the OpenAPI spec: %v", method, path, err)
+		return nil
+	}
+	c.covered[route.Method+" "+route.Path] = true
+
+	ctx := context.Background()
+	input := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+	}
+	if err := openapi3filter.ValidateRequest(ctx, input); (err != nil) != invalidRequest {
+		if invalidRequest {
+			c.t.Errorf("%s %s: expected the spec to reject the request", method, path)
+		} else {
+			c.t.Errorf("%s %s: request does not match the spec: %v", method, path, err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, newRequest())
+	respBody := rec.Body.Bytes()
+
+	if rec.Code != wantStatus {
+		c.t.Errorf("%s %s: status %d, want %d (body %s)", method, path, rec.Code, wantStatus, respBody)
+	}
+
+	err = openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(respBody)),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	})
+	if err != nil {
+		c.t.Errorf("%s %s: %d response does not match the spec: %v", method, path, rec.Code, err)
+	}
+	return respBody
+}
+
+// id decodes the "id" field of a JSON object, failing the test if it is missing
+func (c *checker) id(body []byte) int {
+	c.t.Helper()
+	var v struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil || v.ID == 0 {
+		c.t.Fatalf("response has no id: %s", body)
+	}
+	return v.ID
+}
+
+// exercise calls every operation, covering success and error responses
+func (c *checker) exercise() {
+	c.do("GET", "/openapi.json", nil, http.StatusOK, false)
+
+	c.do("GET", "/markets", nil, http.StatusOK, false)
+	c.do("GET", "/markets?sortBy=newest&limit=2&page=2&status=Active", nil, http.StatusOK, false)
+	c.do("GET", "/markets?cursor=abc", nil, http.StatusBadRequest, false)
+
+	market := c.id(c.do("POST", "/markets", map[string]interface{}{
+		"question":         "Will the conformance suite pass?",
+		"category":         "Binary",
+		"endTime":          time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+		"resolutionSource": "https://example.com/results",
+		"tags":             []string{"testing"},
+	}, http.StatusCreated, false))
+	c.do("POST", "/markets", map[string]interface{}{"question": "Too short"}, http.StatusBadRequest, true)
+	marketPath := fmt.Sprintf("/markets/%d", market)
+
+	c.do("GET", "/markets/search?q=conformance&limit=5", nil, http.StatusOK, false)
+	c.do("GET", "/markets/search", nil, http.StatusBadRequest, true)
+
+	c.do("GET", marketPath, nil, http.StatusOK, false)
+	c.do("GET", "/markets/999999", nil, http.StatusNotFound, false)
+
+	c.do("PUT", marketPath+"/tags", map[string]interface{}{"tags": []string{"testing", "openapi"}}, http.StatusOK, false)
+	c.do("PUT", marketPath+"/tags", map[string]interface{}{"tags": []string{"not a tag"}}, http.StatusBadRequest, false)
+
+	c.do("POST", "/bet", map[string]interface{}{"marketId": market, "outcome": "Yes", "amount": 100}, http.StatusOK, false)
+	c.do("POST", "/bet", map[string]interface{}{"marketId": market, "outcome": "Maybe", "amount": 100}, http.StatusBadRequest, true)
+	c.do("POST", "/bet", map[string]interface{}{"marketId": 999999, "outcome": "No", "amount": 10}, http.StatusNotFound, false)
+
+	c.do("GET", marketPath+"/history?interval=1m", nil, http.StatusOK, false)
+	c.do("GET", marketPath+"/history?interval=2m", nil, http.StatusBadRequest, true)
+	c.do("GET", marketPath+"/trades?limit=1", nil, http.StatusOK, false)
+	c.do("GET", "/trades?user=default", nil, http.StatusOK, false)
+	c.do("GET", "/trades?limit=0", nil, http.StatusBadRequest, true)
+
+	c.do("GET", "/leaderboard?metric=volume&period=7d", nil, http.StatusOK, false)
+	c.do("GET", "/leaderboard?metric=luck", nil, http.StatusBadRequest, true)
+
+	c.do("GET", "/positions", nil, http.StatusOK, false)
+	c.do("GET", "/balance", nil, http.StatusOK, false)
+	c.do("GET", "/portfolio", nil, http.StatusOK, false)
+
+	c.do("POST", fmt.Sprintf("/claim/%d", market), nil, http.StatusBadRequest, false)
+	c.do("POST", marketPath+"/resolve", map[string]interface{}{"outcome": "Yes"}, http.StatusOK, false)
+	c.do("POST", fmt.Sprintf("/claim/%d", market), nil, http.StatusOK, false)
+	c.do("POST", "/claim/999999", nil, http.StatusNotFound, false)
+
+	c.do("GET", "/categories", nil, http.StatusOK, false)
+	category := c.id(c.do("POST", "/categories", map[string]interface{}{"name": "Conformance"}, http.StatusCreated, false))
+	c.do("POST", "/categories", map[string]interface{}{"name": "Conformance"}, http.StatusConflict, false)
+	c.do("PUT", fmt.Sprintf("/categories/%d", category), map[string]interface{}{"name": "Conformance Suite"}, http.StatusOK, false)
+	c.do("PUT", "/categories/999999", map[string]interface{}{"name": "Missing"}, http.StatusNotFound, false)
+	c.do("DELETE", fmt.Sprintf("/categories/%d", category), nil, http.StatusNoContent, false)
+	c.do("DELETE", "/categories/999999", nil, http.StatusNotFound, false)
+
+	c.do("POST", "/graphql", map[string]interface{}{"query": "{ balance markets(limit: 1) { total markets { id odds { yes } } } }"}, http.StatusOK, false)
+	c.do("POST", "/graphql", map[string]interface{}{
+		"query":     "mutation($id: Int!) { placeBet(marketId: $id, outcome: Yes, amount: 1) { success } }",
+		"variables": map[string]interface{}{"id": 999999},
+	}, http.StatusOK, false)
+	c.do("POST", "/graphql", map[string]interface{}{}, http.StatusBadRequest, true)
+	c.do("GET", "/graphql?query="+url.QueryEscape("{ trades(limit: 1) { nextCursor } }"), nil, http.StatusOK, false)
+	c.do("GET", "/graphql?query="+url.QueryEscape("mutation { claimWinnings(marketId: 1) { payout } }"), nil, http.StatusBadRequest, false)
+
+	// Streaming endpoints are only checked up to their error responses
+	c.do("GET", "/ws", nil, http.StatusBadRequest, false)
+	c.do("GET", "/stream?lastEventId=abc", nil, http.StatusBadRequest, true)
+}
@@ -6,6 +6,10 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -39,34 +43,72 @@ func NewCoinGeckoClient() *CoinGeckoClient {
 	}
 }
 
+// coinGeckoMaxPerPage is the largest page /coins/markets returns
+const coinGeckoMaxPerPage = 250
+
 // GetTopCoins fetches top N cryptocurrencies by market cap
 func (c *CoinGeckoClient) GetTopCoins(limit int) ([]CoinPrice, error) {
-	url := fmt.Sprintf("%s/coins/markets?vs_currency=usd&order=market_cap_desc&per_page=%d&page=1&sparkline=false", 
-		c.baseURL, limit)
-	
-	req, err := http.NewRequest("GET", url, nil)
+	return c.fetchMarkets(url.Values{
+		"vs_currency": {"usd"},
+		"order":       {"market_cap_desc"},
+		"per_page":    {strconv.Itoa(limit)},
+		"page":        {"1"},
+		"sparkline":   {"false"},
+	})
+}
+
+// GetCoins fetches the given coins by CoinGecko ID, largest market cap first
+func (c *CoinGeckoClient) GetCoins(ids []string) ([]CoinPrice, error) {
+	var coins []CoinPrice
+
+	// Without per_page CoinGecko returns only the first 100 coins, so ask for
+	// each batch of IDs in full
+	for start := 0; start < len(ids); start += coinGeckoMaxPerPage {
+		batch := ids[start:min(start+coinGeckoMaxPerPage, len(ids))]
+
+		// IDs come from the rules file, so they are escaped rather than trusted
+		page, err := c.fetchMarkets(url.Values{
+			"vs_currency": {"usd"},
+			"order":       {"market_cap_desc"},
+			"ids":         {strings.Join(batch, ",")},
+			"per_page":    {strconv.Itoa(len(batch))},
+			"sparkline":   {"false"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, page...)
+	}
+
+	sort.SliceStable(coins, func(i, j int) bool { return coins[i].MarketCap > coins[j].MarketCap })
+	return coins, nil
+}
+
+// fetchMarkets calls /coins/markets with the given query
+func (c *CoinGeckoClient) fetchMarkets(query url.Values) ([]CoinPrice, error) {
+	req, err := http.NewRequest("GET", c.baseURL+"/coins/markets?"+query.Encode(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	
+
 	req.Header.Set("Accept", "application/json")
-	
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch data: %w", err)
 	}
 	defer resp.Body.Close()
-	
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
 	}
-	
+
 	var coins []CoinPrice
 	if err := json.NewDecoder(resp.Body).Decode(&coins); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	
+
 	return coins, nil
 }
 
@@ -0,0 +1,72 @@
+package oracle
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGetCoinsEscapesIDs(t *testing.T) {
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewCoinGeckoClient()
+	client.baseURL = server.URL
+	if _, err := client.GetCoins([]string{"bitcoin", "evil&per_page=1#x"}); err != nil {
+		t.Fatalf("GetCoins: %v", err)
+	}
+
+	if ids := got.Get("ids"); ids != "bitcoin,evil&per_page=1#x" {
+		t.Errorf("ids = %q, want the IDs unchanged", ids)
+	}
+	if perPage := got["per_page"]; len(perPage) != 1 || perPage[0] != "2" {
+		t.Errorf("per_page = %v, want one value of 2", perPage)
+	}
+}
+
+func TestGetCoinsRequestsEveryID(t *testing.T) {
+	var perPage []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		perPage = append(perPage, query.Get("per_page"))
+
+		// Echo the requested coins, each worth its position in the ID list
+		var coins []CoinPrice
+		for _, id := range strings.Split(query.Get("ids"), ",") {
+			n, _ := strconv.Atoi(strings.TrimPrefix(id, "coin-"))
+			coins = append(coins, CoinPrice{ID: id, MarketCap: float64(n)})
+		}
+		json.NewEncoder(w).Encode(coins)
+	}))
+	defer server.Close()
+
+	ids := make([]string, 300)
+	for i := range ids {
+		ids[i] = "coin-" + strconv.Itoa(i)
+	}
+
+	client := NewCoinGeckoClient()
+	client.baseURL = server.URL
+	coins, err := client.GetCoins(ids)
+	if err != nil {
+		t.Fatalf("GetCoins: %v", err)
+	}
+
+	if len(perPage) != 2 || perPage[0] != "250" || perPage[1] != "50" {
+		t.Errorf("per_page of each request = %v, want [250 50]", perPage)
+	}
+	if len(coins) != len(ids) {
+		t.Fatalf("got %d coins, want %d", len(coins), len(ids))
+	}
+	if coins[0].ID != "coin-299" || coins[len(coins)-1].ID != "coin-0" {
+		t.Errorf("coins run from %s to %s, want largest market cap first", coins[0].ID, coins[len(coins)-1].ID)
+	}
+}
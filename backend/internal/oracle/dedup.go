@@ -0,0 +1,114 @@
+package oracle
+
+import (
+	"expvar"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/market"
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// stats counts what the oracle did with the markets it generated; it is
+// published as "oracle" at /debug/vars
+var stats = expvar.NewMap("oracle")
+
+// Keys of stats
+const (
+	statCreated          = "created"
+	statSkippedDuplicate = "skipped_duplicate" // too similar to an active market
+	statSkippedMaxActive = "skipped_max_active"
+	statSkippedInvalid   = "skipped_invalid" // rejected by market validation for another reason
+)
+
+// DedupRules decide when a generated price market repeats an active one: both
+// are about the same coin, their target prices are within ThresholdPercent of
+// each other and their horizons (end time minus creation time) are within
+// HorizonTolerance.
+type DedupRules struct {
+	Enabled          bool          `yaml:"enabled" toml:"enabled"`
+	ThresholdPercent float64       `yaml:"threshold_percent" toml:"threshold_percent"`
+	HorizonTolerance time.Duration `yaml:"horizon_tolerance" toml:"horizon_tolerance"`
+}
+
+// pricePattern finds the target price in a question, as formatPrice writes it
+var pricePattern = regexp.MustCompile(`\$([0-9][0-9,]*(?:\.[0-9]+)?)`)
+
+// duplicateOf returns the active market a generated price market about coin
+// repeats, or nil if there is none
+func (d DedupRules) duplicateOf(req market.CreateRequest, coin string, active []*models.Market) *models.Market {
+	if !d.Enabled {
+		return nil
+	}
+	price, ok := questionPrice(req.Question)
+	if !ok {
+		return nil
+	}
+	horizon := req.EndTime.Sub(req.CreatedAt)
+
+	for _, m := range active {
+		if m.ResolutionSource != req.ResolutionSource || !hasTag(m, coin) {
+			continue
+		}
+		other, ok := questionPrice(m.Question)
+		if !ok {
+			continue
+		}
+		if d.similarPrice(price, other) && d.similarHorizon(horizon, m.EndTime.Sub(m.CreatedAt)) {
+			return m
+		}
+	}
+	return nil
+}
+
+func (d DedupRules) similarPrice(a, b float64) bool {
+	larger := a
+	if b > larger {
+		larger = b
+	}
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= larger*d.ThresholdPercent/100
+}
+
+func (d DedupRules) similarHorizon(a, b time.Duration) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= d.HorizonTolerance
+}
+
+// questionPrice returns the first dollar amount in a question
+func questionPrice(question string) (float64, bool) {
+	match := pricePattern.FindStringSubmatch(question)
+	if match == nil {
+		return 0, false
+	}
+	price, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+	return price, err == nil
+}
+
+// coinTag is the tag a price market about a coin carries: its CoinGecko ID,
+// cut to the longest tag allowed
+func coinTag(coin CoinPrice) string {
+	tag := strings.ToLower(coin.ID)
+	if len(tag) > 32 {
+		tag = strings.TrimRight(tag[:32], "-")
+	}
+	return tag
+}
+
+// hasTag reports whether m carries tag, ignoring case
+func hasTag(m *models.Market, tag string) bool {
+	for _, t := range m.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
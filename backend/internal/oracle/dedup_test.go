@@ -0,0 +1,155 @@
+package oracle
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/market"
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+var now = time.Now().UTC()
+
+// priceMarket is an active oracle price market carrying tag, ending horizon
+// after it was created
+func priceMarket(id int, tag, question string, horizon time.Duration) *models.Market {
+	return &models.Market{
+		ID:               id,
+		Question:         question,
+		Status:           models.StatusActive,
+		Tags:             []string{tag},
+		ResolutionSource: "oracle:coingecko",
+		CreatedAt:        now,
+		EndTime:          now.Add(horizon),
+	}
+}
+
+func TestDuplicateOf(t *testing.T) {
+	req := market.CreateRequest{
+		Question:         "Will Bitcoin price be above $105000 in 24 hours?",
+		Tags:             []string{"bitcoin"},
+		ResolutionSource: "oracle:coingecko",
+		CreatedAt:        now,
+		EndTime:          now.Add(24 * time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		coin    CoinPrice
+		active  *models.Market
+		enabled bool
+		wantDup bool
+	}{
+		{name: "within the threshold", coin: CoinPrice{ID: "bitcoin"}, active: priceMarket(1, "bitcoin", "Will Bitcoin reach $106,000 by tomorrow?", 30*time.Hour), enabled: true, wantDup: true},
+		{name: "coin ID in another case", coin: CoinPrice{ID: "BitCoin"}, active: priceMarket(1, "bitcoin", "Will Bitcoin price be above $105000 in 24 hours?", 24*time.Hour), enabled: true, wantDup: true},
+		{name: "tag in another case", coin: CoinPrice{ID: "bitcoin"}, active: priceMarket(1, "Bitcoin", "Will Bitcoin price be above $105000 in 24 hours?", 24*time.Hour), enabled: true, wantDup: true},
+		{name: "price too far apart", coin: CoinPrice{ID: "bitcoin"}, active: priceMarket(1, "bitcoin", "Will Bitcoin price be above $95,000 in 24 hours?", 24*time.Hour), enabled: true},
+		{name: "horizon too far apart", coin: CoinPrice{ID: "bitcoin"}, active: priceMarket(1, "bitcoin", "Will Bitcoin reach $105000 by next week?", 7*24*time.Hour), enabled: true},
+		{name: "another coin", coin: CoinPrice{ID: "bitcoin"}, active: priceMarket(1, "bitcoin-cash", "Will Bitcoin Cash price be above $105000 in 24 hours?", 24*time.Hour), enabled: true},
+		{name: "disabled", coin: CoinPrice{ID: "bitcoin"}, active: priceMarket(1, "bitcoin", "Will Bitcoin price be above $105000 in 24 hours?", 24*time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DefaultRules().Dedup
+			d.Enabled = tt.enabled
+
+			dup := d.duplicateOf(req, coinTag(tt.coin), []*models.Market{tt.active})
+			if (dup != nil) != tt.wantDup {
+				t.Errorf("duplicateOf = %v, want a duplicate: %v", dup, tt.wantDup)
+			}
+		})
+	}
+}
+
+// fakeMarkets lists the markets it holds by status and records creations
+type fakeMarkets struct {
+	markets []*models.Market
+	created []market.CreateRequest
+}
+
+func (f *fakeMarkets) List(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error) {
+	page := &models.MarketPage{}
+	for _, m := range f.markets {
+		if q.Status == "" || string(m.Status) == q.Status {
+			page.Markets = append(page.Markets, m)
+		}
+	}
+	return page, nil
+}
+
+func (f *fakeMarkets) Create(ctx context.Context, req market.CreateRequest) (*models.Market, error) {
+	f.created = append(f.created, req)
+	return &models.Market{ID: 100 + len(f.created), Question: req.Question, EndTime: req.EndTime}, nil
+}
+
+func (f *fakeMarkets) Expired(ctx context.Context) ([]*models.Market, error) { return nil, nil }
+
+func (f *fakeMarkets) Resolve(ctx context.Context, id int, outcome models.Outcome) (*models.Market, error) {
+	return nil, nil
+}
+
+func statValue(key string) int64 {
+	if v, ok := stats.Get(key).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+func TestCreateSkipsDuplicates(t *testing.T) {
+	coinGecko := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": "bitcoin", "symbol": "btc", "name": "Bitcoin", "current_price": 100000}]`))
+	}))
+	defer coinGecko.Close()
+
+	// One coin and one template, so every candidate is the same market
+	rules := DefaultRules()
+	rules.Coins = CoinRules{IDs: []string{"bitcoin"}}
+	rules.Templates = []Template{{Question: "Will {coin} price be above {price} in 24 hours?", ChangePercent: 5, Duration: 24 * time.Hour}}
+	existing := "Will Bitcoin price be above $105000 in 24 hours?"
+
+	tests := []struct {
+		name        string
+		status      models.MarketStatus
+		wantCreated bool
+	}{
+		{name: "active market blocks", status: models.StatusActive},
+		{name: "resolved market does not block", status: models.StatusResolved, wantCreated: true},
+		{name: "cancelled market does not block", status: models.StatusCancelled, wantCreated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := priceMarket(1, "bitcoin", existing, 24*time.Hour)
+			m.Status = tt.status
+			markets := &fakeMarkets{markets: []*models.Market{m}}
+
+			o := NewOracle(markets, rules)
+			o.coinGecko.baseURL = coinGecko.URL
+			skipped, created := statValue(statSkippedDuplicate), statValue(statCreated)
+
+			o.createMarketFromRealData()
+
+			if got := len(markets.created) == 1; got != tt.wantCreated {
+				t.Fatalf("created %v, want a market: %v", markets.created, tt.wantCreated)
+			}
+			wantSkipped, wantCreated := int64(1), int64(0)
+			if tt.wantCreated {
+				wantSkipped, wantCreated = 0, 1
+				if markets.created[0].Question != existing {
+					t.Errorf("created %q, want %q", markets.created[0].Question, existing)
+				}
+			}
+			if got := statValue(statSkippedDuplicate) - skipped; got != wantSkipped {
+				t.Errorf("skipped_duplicate grew by %d, want %d", got, wantSkipped)
+			}
+			if got := statValue(statCreated) - created; got != wantCreated {
+				t.Errorf("created counter grew by %d, want %d", got, wantCreated)
+			}
+		})
+	}
+}
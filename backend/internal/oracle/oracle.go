@@ -1,57 +1,134 @@
 package oracle
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"log"
 	"math/rand"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/linera-prediction-market/backend/internal/market"
 	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/validation"
 )
 
-// StorageInterface defines the methods required for oracle operations
-type StorageInterface interface {
-	SaveMarket(market *models.Market) error
-	GetExpiredMarkets() ([]*models.Market, error)
-	UpdateMarket(market *models.Market) error
+// MarketService defines the market operations the oracle drives
+type MarketService interface {
+	List(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error)
+	Create(ctx context.Context, req market.CreateRequest) (*models.Market, error)
+	Expired(ctx context.Context) ([]*models.Market, error)
+	Resolve(ctx context.Context, id int, outcome models.Outcome) (*models.Market, error)
 }
 
+// rulesCheckInterval is how often a watched rules file is checked for changes
+const rulesCheckInterval = 10 * time.Second
+
+// oracleSourcePrefix starts the resolution source of every oracle market
+const oracleSourcePrefix = "oracle:"
+
+// pageSize is how many markets activeMarkets reads at a time
+const pageSize = 100
+
 // Oracle automatically creates prediction markets
 type Oracle struct {
-	storage       StorageInterface
+	markets       MarketService
 	createTicker  *time.Ticker
 	resolveTicker *time.Ticker
 	done          chan bool
+	wg            sync.WaitGroup
 	coinGecko     *CoinGeckoClient
 	lastPrices    map[string]float64 // Cache of last known prices
+	production    bool
+
+	mu            sync.RWMutex
+	rules         *Rules
+	rulesPath     string // file the rules are reloaded from; empty if they are fixed
+	rulesModified time.Time
 }
 
-// NewOracleWithStorage creates a new oracle instance with storage interface
-func NewOracleWithStorage(s StorageInterface) *Oracle {
+// NewOracle creates a new oracle instance that creates and resolves markets through m
+// following rules
+func NewOracle(m MarketService, rules *Rules) *Oracle {
 	return &Oracle{
-		storage:    s,
+		markets:    m,
 		done:       make(chan bool),
 		coinGecko:  NewCoinGeckoClient(),
 		lastPrices: make(map[string]float64),
+		rules:      rules,
+	}
+}
+
+// SetProduction switches the oracle to production mode, where markets are
+// created with the current time, start with the rules' house liquidity in each
+// pool (none by default) and are never made up from demo templates when
+// CoinGecko is unavailable. Call it before Start.
+func (o *Oracle) SetProduction(production bool) {
+	o.production = production
+}
+
+// WatchRules makes the running oracle reload its rules whenever the file at
+// path changes. Call it before Start. Invalid rules are logged and the
+// current ones kept.
+func (o *Oracle) WatchRules(path string) {
+	o.rulesPath = path
+	if info, err := os.Stat(path); err == nil {
+		o.rulesModified = info.ModTime()
 	}
 }
 
+// currentRules returns the rules in effect
+func (o *Oracle) currentRules() *Rules {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.rules
+}
+
 // Start begins the oracle service
-// Creates new markets every 5 minutes and resolves expired markets every 5 minutes
+// Creates new markets and resolves expired markets on the intervals set by the rules
 func (o *Oracle) Start() {
+	rules := o.currentRules()
+
 	log.Println("🔮 Oracle service started with REAL CoinGecko data")
-	log.Println("   📝 Creating markets every 5 minutes")
-	log.Println("   ✅ Resolving expired markets every 5 minutes")
+	log.Printf("   📝 Creating markets every %s from %d template(s)", rules.CreateInterval, len(rules.Templates))
+	log.Printf("   ✅ Resolving expired markets every %s", rules.ResolveInterval)
 	log.Println("   💰 Fetching real crypto prices from CoinGecko API")
+	if o.production {
+		log.Printf("   🏭 Production mode: %g tokens of house liquidity per pool, no demo markets", rules.HouseLiquidity)
+	}
 
 	// Fetch initial prices
 	o.fetchAndCachePrices()
 
-	o.createTicker = time.NewTicker(5 * time.Minute)
-	o.resolveTicker = time.NewTicker(5 * time.Minute)
+	o.createTicker = time.NewTicker(rules.CreateInterval)
+	o.resolveTicker = time.NewTicker(rules.ResolveInterval)
+
+	if o.rulesPath != "" {
+		log.Printf("   👀 Watching %s for rule changes", o.rulesPath)
+
+		o.wg.Add(1)
+		go func() {
+			defer o.wg.Done()
+			ticker := time.NewTicker(rulesCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					o.reloadRules()
+				case <-o.done:
+					return
+				}
+			}
+		}()
+	}
+
+	o.wg.Add(2)
 
 	// Market creation goroutine
 	go func() {
+		defer o.wg.Done()
 		for {
 			select {
 			case <-o.createTicker.C:
@@ -64,6 +141,7 @@ func (o *Oracle) Start() {
 
 	// Market resolution goroutine
 	go func() {
+		defer o.wg.Done()
 		for {
 			select {
 			case <-o.resolveTicker.C:
@@ -75,7 +153,7 @@ func (o *Oracle) Start() {
 	}()
 }
 
-// Stop stops the oracle service
+// Stop stops the oracle service, waiting for a creation or resolution in progress to finish
 func (o *Oracle) Stop() {
 	if o.createTicker != nil {
 		o.createTicker.Stop()
@@ -84,114 +162,207 @@ func (o *Oracle) Stop() {
 		o.resolveTicker.Stop()
 	}
 	close(o.done)
+	o.wg.Wait()
 	log.Println("🔮 Oracle service stopped")
 }
 
+// reloadRules swaps in the rules file if it changed since it was last read
+func (o *Oracle) reloadRules() {
+	info, err := os.Stat(o.rulesPath)
+	if err != nil {
+		log.Printf("⚠️  Failed to check oracle rules: %v", err)
+		return
+	}
+	if info.ModTime().Equal(o.rulesModified) {
+		return
+	}
+	o.rulesModified = info.ModTime()
+
+	rules, err := LoadRules(o.rulesPath)
+	if err != nil {
+		log.Printf("⚠️  Keeping current oracle rules: %v", err)
+		return
+	}
+
+	o.mu.Lock()
+	o.rules = rules
+	o.mu.Unlock()
+
+	o.createTicker.Reset(rules.CreateInterval)
+	o.resolveTicker.Reset(rules.ResolveInterval)
+
+	log.Printf("🔄 Reloaded oracle rules from %s (%d template(s), creating every %s, resolving every %s)",
+		o.rulesPath, len(rules.Templates), rules.CreateInterval, rules.ResolveInterval)
+}
+
 // fetchAndCachePrices fetches current crypto prices from CoinGecko and caches them
 func (o *Oracle) fetchAndCachePrices() {
-	coins, err := o.coinGecko.GetTopCoins(15) // Fetch top 15 coins
+	coins, err := o.fetchCoins(o.currentRules().Coins)
 	if err != nil {
 		log.Printf("⚠️  Failed to fetch prices from CoinGecko: %v", err)
-		log.Println("   Will use fallback mock data for this cycle")
+		if !o.production {
+			log.Println("   Will use fallback mock data for this cycle")
+		}
 		return
 	}
 
-	// Cache prices
+	// Log current prices
+	o.coinGecko.LogPrices(coins)
+}
+
+// fetchCoins fetches the coins allowed by the rules from CoinGecko and caches their prices
+func (o *Oracle) fetchCoins(rules CoinRules) ([]CoinPrice, error) {
+	var coins []CoinPrice
+	var err error
+	if len(rules.IDs) > 0 {
+		coins, err = o.coinGecko.GetCoins(rules.IDs)
+	} else {
+		coins, err = o.coinGecko.GetTopCoins(rules.Top)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make([]CoinPrice, 0, len(coins))
 	for _, coin := range coins {
 		o.lastPrices[coin.ID] = coin.CurrentPrice
+		if !rules.excluded(coin) {
+			allowed = append(allowed, coin)
+		}
 	}
+	if len(allowed) == 0 {
+		return nil, errors.New("no coins match the oracle rules")
+	}
+	return allowed, nil
+}
 
-	// Log current prices
-	o.coinGecko.LogPrices(coins)
+// activeMarkets returns the active markets the oracle created
+func (o *Oracle) activeMarkets(ctx context.Context) ([]*models.Market, error) {
+	var active []*models.Market
+	query := models.MarketQuery{Status: string(models.StatusActive), SortBy: models.SortNewest, Limit: pageSize}
+	for {
+		page, err := o.markets.List(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page.Markets {
+			if strings.HasPrefix(m.ResolutionSource, oracleSourcePrefix) {
+				active = append(active, m)
+			}
+		}
+		if len(page.Markets) < pageSize {
+			return active, nil
+		}
+		query.After = page.Markets[len(page.Markets)-1].ID
+	}
 }
 
-// createMarketFromRealData creates a market using real CoinGecko data
+// maxCandidates is how many generated markets the oracle tries per creation
+// before giving up until the next tick
+const maxCandidates = 5
+
+// createMarketFromRealData creates a market using real CoinGecko data,
+// skipping candidates that repeat an active market
 func (o *Oracle) createMarketFromRealData() {
-	// Fetch latest prices (with rate limiting - every 5 minutes is safe for free tier)
-	coins, err := o.coinGecko.GetTopCoins(15)
+	ctx := context.Background()
+	rules := o.currentRules()
+
+	var active []*models.Market
+	if rules.MaxActive > 0 || rules.Dedup.Enabled {
+		var err error
+		active, err = o.activeMarkets(ctx)
+		if err != nil {
+			log.Printf("❌ Oracle failed to list active markets: %v", err)
+			return
+		}
+	}
+	if rules.MaxActive > 0 && len(active) >= rules.MaxActive {
+		stats.Add(statSkippedMaxActive, 1)
+		log.Printf("⏸️  Oracle has %d active market(s), the most its rules allow; skipping creation", len(active))
+		return
+	}
+
+	// Fetch latest prices; each creation tick makes one CoinGecko request
+	coins, err := o.fetchCoins(rules.Coins)
+	if err != nil && o.production {
+		log.Printf("⚠️  CoinGecko API error: %v, skipping creation", err)
+		return
+	}
 	if err != nil {
 		log.Printf("⚠️  CoinGecko API error: %v, falling back to mock market", err)
 		o.createRandomMarket() // Fallback to mock data
 		return
 	}
 
-	// Update cache
-	for _, coin := range coins {
-		o.lastPrices[coin.ID] = coin.CurrentPrice
-	}
+	tried := make(map[string]bool)
+	for i := 0; i < maxCandidates; i++ {
+		// Pick a random coin from the allowed coins
+		coin := coins[rand.Intn(len(coins))]
 
-	// Pick a random coin from top 15
-	coin := coins[rand.Intn(len(coins))]
+		// Generate market based on current price
+		req := o.generateCryptoMarket(coin, rules.pickTemplate(), rules.HouseLiquidity)
+		if tried[req.Question] {
+			continue
+		}
+		tried[req.Question] = true
 
-	// Generate market based on current price
-	market := o.generateCryptoMarket(coin)
+		if dup := rules.Dedup.duplicateOf(req, coinTag(coin), active); dup != nil {
+			stats.Add(statSkippedDuplicate, 1)
+			log.Printf("♻️  Oracle skipped %q: too similar to active market #%d", req.Question, dup.ID)
+			continue
+		}
+
+		created, err := o.markets.Create(ctx, req)
+		var fieldErrs validation.Errors
+		if errors.As(err, &fieldErrs) {
+			o.countRejected(fieldErrs)
+			log.Printf("⚠️  Oracle skipped market %q: %v", req.Question, err)
+			continue
+		}
+		if err != nil {
+			log.Printf("❌ Oracle failed to create market: %v", err)
+			return
+		}
 
-	// Save to database
-	if err := o.storage.SaveMarket(market); err != nil {
-		log.Printf("❌ Oracle failed to create market: %v", err)
+		stats.Add(statCreated, 1)
+		log.Printf("🎯 Oracle created REAL market #%d: %s (Current: $%.2f)",
+			created.ID,
+			created.Question,
+			coin.CurrentPrice)
 		return
 	}
 
-	log.Printf("🎯 Oracle created REAL market #%d: %s (Current: $%.2f)",
-		market.ID,
-		market.Question,
-		coin.CurrentPrice)
+	log.Printf("⚠️  Oracle found no new market in %d tries", maxCandidates)
 }
 
-// generateCryptoMarket generates a prediction market based on real crypto data
-func (o *Oracle) generateCryptoMarket(coin CoinPrice) *models.Market {
-	now := time.Now().UTC() // Use UTC to avoid timezone issues
-
-	// Market types with different timeframes
-	marketTypes := []struct {
-		template    string
-		duration    time.Duration
-		targetPrice float64
-	}{
-		// Short-term (24h)
-		{
-			template:    "Will %s price be above %s in 24 hours?",
-			duration:    24 * time.Hour,
-			targetPrice: coin.CurrentPrice * 1.05, // 5% increase
-		},
-		// Medium-term (7 days)
-		{
-			template:    "Will %s reach %s by next week?",
-			duration:    7 * 24 * time.Hour,
-			targetPrice: coin.CurrentPrice * 1.10, // 10% increase
-		},
-		// Long-term (30 days)
-		{
-			template:    "Will %s break %s by end of month?",
-			duration:    30 * 24 * time.Hour,
-			targetPrice: coin.CurrentPrice * 1.20, // 20% increase
-		},
-		// Specific milestone
-		{
-			template:    "Will %s stay above %s for next 48 hours?",
-			duration:    48 * time.Hour,
-			targetPrice: coin.CurrentPrice * 0.95, // 5% below current
-		},
-	}
-
-	// Pick random market type
-	marketType := marketTypes[rand.Intn(len(marketTypes))]
-
-	// Generate question with coin name and target price
-	// Format price appropriately based on value
-	var priceStr string
-	if marketType.targetPrice >= 1000 {
-		priceStr = fmt.Sprintf("$%.0f", marketType.targetPrice)
-	} else if marketType.targetPrice >= 1 {
-		priceStr = fmt.Sprintf("$%.2f", marketType.targetPrice)
+// countRejected records why market validation rejected a generated market
+func (o *Oracle) countRejected(errs validation.Errors) {
+	if errs.Has("question", validation.CodeDuplicate) {
+		stats.Add(statSkippedDuplicate, 1)
 	} else {
-		priceStr = fmt.Sprintf("$%.4f", marketType.targetPrice)
+		stats.Add(statSkippedInvalid, 1)
 	}
+}
 
-	question := fmt.Sprintf(marketType.template, coin.Name, priceStr)
+// generateCryptoMarket generates a prediction market from a template based on real crypto data.
+// In production mode both pools start with houseLiquidity.
+func (o *Oracle) generateCryptoMarket(coin CoinPrice, t Template, houseLiquidity float64) market.CreateRequest {
+	now := time.Now().UTC() // Use UTC to avoid timezone issues
 
-	// Calculate end time
-	endTime := now.Add(marketType.duration)
+	targetPrice := coin.CurrentPrice * (1 + t.ChangePercent/100)
+	req := market.CreateRequest{
+		Question:         t.question(coin, targetPrice),
+		Category:         "Crypto",
+		Tags:             []string{coinTag(coin)},
+		EndTime:          now.Add(t.Duration),
+		ResolutionSource: "oracle:coingecko",
+		YesPool:          houseLiquidity,
+		NoPool:           houseLiquidity,
+		CreatedAt:        now,
+	}
+	if o.production {
+		return req
+	}
 
 	// Generate realistic initial pools based on market cap
 	// Higher market cap = more initial liquidity
@@ -214,24 +385,13 @@ func (o *Oracle) generateCryptoMarket(coin CoinPrice) *models.Market {
 		initialNoPool *= 1.3
 	}
 
-	return &models.Market{
-		Question:       question,
-		Category:       "Crypto",
-		Status:         models.StatusActive,
-		EndTime:        endTime,
-		YesPool:        initialYesPool,
-		NoPool:         initialNoPool,
-		TotalYesShares: initialYesPool * 1000,
-		TotalNoShares:  initialNoPool * 1000,
-		WinningOutcome: nil,
-		CreatedAt:      now,
-	}
+	req.YesPool = initialYesPool
+	req.NoPool = initialNoPool
+	return req
 }
 
 // createRandomMarket creates a new prediction market with realistic future dates
 func (o *Oracle) createRandomMarket() {
-	now := time.Now().UTC() // Use UTC to avoid timezone issues
-
 	// Market templates with realistic future dates (30+ templates)
 	templates := []struct {
 		question string
@@ -289,62 +449,60 @@ func (o *Oracle) createRandomMarket() {
 		{"Will gold price reach $2500/oz in next 60 days?", "Binary", 60 * 24 * time.Hour},
 	}
 
-	// Pick a random template (avoid duplicates by tracking recent questions)
-	template := templates[rand.Intn(len(templates))]
-
-	// Add unique timestamp to make questions distinguishable
-	now = time.Now()
-	questionWithTime := template.question
+	// Try templates in random order until one passes validation; stale
+	// dates and questions that are already active are skipped
+	var created *models.Market
+	for _, i := range rand.Perm(len(templates)) {
+		req := o.templateMarket(templates[i].question, templates[i].category, templates[i].duration)
 
-	// For Bitcoin/Crypto price predictions, add specific time context
-	if template.category == "Crypto" && (template.question == "Will Bitcoin reach $110,000 by end of December 2025?" ||
-		template.question == "Will Bitcoin price be above $95,000 in 24 hours?" ||
-		template.question == "Will Bitcoin break $120,000 before Q2 2026?" ||
-		template.question == "Will Ethereum surpass $6,000 by end of this week?" ||
-		template.question == "Will Ethereum price be above $5,000 by end of November 2025?") {
-		// Add specific date/time to make it unique
-		questionWithTime = fmt.Sprintf("%s [Created: %s]", template.question, now.Format("Jan 02, 15:04"))
+		m, err := o.markets.Create(context.Background(), req)
+		if err == nil {
+			created = m
+			break
+		}
+		var fieldErrs validation.Errors
+		if !errors.As(err, &fieldErrs) {
+			log.Printf("❌ Oracle failed to create market: %v", err)
+			return
+		}
+		o.countRejected(fieldErrs)
 	}
-
-	// Calculate end time (ensure it's in the future)
-	endTime := now.Add(template.duration)
-	if endTime.Before(now) {
-		// If calculated time is in the past, add to current time instead
-		endTime = now.Add(template.duration)
+	if created == nil {
+		log.Println("⚠️  Oracle found no valid market template to create")
+		return
 	}
 
+	stats.Add(statCreated, 1)
+	log.Printf("🎯 Oracle created market #%d: %s (ends: %s)",
+		created.ID,
+		created.Question,
+		created.EndTime.Format("2006-01-02 15:04 MST"))
+}
+
+// templateMarket builds a demo market from a template with random initial pools.
+// A template whose question is already active is rejected by market validation.
+func (o *Oracle) templateMarket(question, category string, duration time.Duration) market.CreateRequest {
+	now := time.Now()
+
 	// Create market with random initial pools
 	initialYesPool := float64(rand.Intn(4000) + 500) // 500-4500 tokens
 	initialNoPool := float64(rand.Intn(4000) + 500)  // 500-4500 tokens
 
-	market := &models.Market{
-		Question:       questionWithTime,
-		Category:       template.category,
-		Status:         models.StatusActive,
-		EndTime:        endTime,
-		YesPool:        initialYesPool,
-		NoPool:         initialNoPool,
-		TotalYesShares: initialYesPool * 1000, // Initial shares
-		TotalNoShares:  initialNoPool * 1000,
-		WinningOutcome: nil,
-		CreatedAt:      now.Add(-time.Duration(rand.Intn(168)) * time.Hour), // Created 0-7 days ago
-	}
-
-	// Save to database
-	if err := o.storage.SaveMarket(market); err != nil {
-		log.Printf("❌ Oracle failed to create market: %v", err)
-		return
+	return market.CreateRequest{
+		Question:         question,
+		Category:         category,
+		EndTime:          now.Add(duration),
+		ResolutionSource: "oracle:demo",
+		YesPool:          initialYesPool,
+		NoPool:           initialNoPool,
+		CreatedAt:        now.Add(-time.Duration(rand.Intn(168)) * time.Hour), // Created 0-7 days ago
 	}
-
-	log.Printf("🎯 Oracle created market #%d: %s (ends: %s)",
-		market.ID,
-		market.Question,
-		endTime.Format("2006-01-02 15:04 MST"))
 }
 
 // resolveExpiredMarkets automatically resolves markets that have passed their end time
 func (o *Oracle) resolveExpiredMarkets() {
-	expiredMarkets, err := o.storage.GetExpiredMarkets()
+	ctx := context.Background()
+	expiredMarkets, err := o.markets.Expired(ctx)
 	if err != nil {
 		log.Printf("❌ Oracle failed to get expired markets: %v", err)
 		return
@@ -365,10 +523,7 @@ func (o *Oracle) resolveExpiredMarkets() {
 			outcome = models.OutcomeNo
 		}
 
-		market.Status = models.StatusResolved
-		market.WinningOutcome = &outcome
-
-		if err := o.storage.UpdateMarket(market); err != nil {
+		if _, err := o.markets.Resolve(ctx, market.ID, outcome); err != nil {
 			log.Printf("❌ Oracle failed to resolve market #%d: %v", market.ID, err)
 			continue
 		}
@@ -0,0 +1,172 @@
+package oracle
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/config"
+)
+
+// Rules declare which markets the oracle generates and how often. They are
+// read from a YAML or TOML file; keys missing from the file keep their
+// DefaultRules value.
+type Rules struct {
+	CreateInterval  time.Duration `yaml:"create_interval" toml:"create_interval"`
+	ResolveInterval time.Duration `yaml:"resolve_interval" toml:"resolve_interval"`
+	MaxActive       int           `yaml:"max_active" toml:"max_active"`           // most oracle markets open at once; 0 for no cap
+	HouseLiquidity  float64       `yaml:"house_liquidity" toml:"house_liquidity"` // tokens the house puts in each pool in production mode
+	Coins           CoinRules     `yaml:"coins" toml:"coins"`
+	Templates       []Template    `yaml:"templates" toml:"templates"`
+	Dedup           DedupRules    `yaml:"dedup" toml:"dedup"`
+}
+
+// CoinRules select the coins markets are generated for
+type CoinRules struct {
+	Top     int      `yaml:"top" toml:"top"`         // draw from this many coins by market cap
+	IDs     []string `yaml:"ids" toml:"ids"`         // CoinGecko IDs to use instead of the top coins
+	Exclude []string `yaml:"exclude" toml:"exclude"` // CoinGecko IDs never used
+}
+
+// Template is a price-threshold market: will the coin's price be above the
+// current price moved by ChangePercent once Duration has passed?
+type Template struct {
+	Question      string        `yaml:"question" toml:"question"` // {coin}, {symbol} and {price} are filled in
+	ChangePercent float64       `yaml:"change_percent" toml:"change_percent"`
+	Duration      time.Duration `yaml:"duration" toml:"duration"`
+	Weight        int           `yaml:"weight" toml:"weight"` // relative chance of being picked; 0 counts as 1
+}
+
+// maxTopCoins is the largest page CoinGecko returns
+const maxTopCoins = 250
+
+// DefaultRules creates a market for one of the top 15 coins every 5 minutes
+// and resolves expired markets every 5 minutes. A market is not created
+// while an active one on the same coin has a target within 2% and a horizon
+// within 12 hours.
+func DefaultRules() *Rules {
+	return &Rules{
+		CreateInterval:  5 * time.Minute,
+		ResolveInterval: 5 * time.Minute,
+		Coins:           CoinRules{Top: 15},
+		Templates: []Template{
+			{Question: "Will {coin} price be above {price} in 24 hours?", ChangePercent: 5, Duration: 24 * time.Hour},
+			{Question: "Will {coin} reach {price} by next week?", ChangePercent: 10, Duration: 7 * 24 * time.Hour},
+			{Question: "Will {coin} break {price} by end of month?", ChangePercent: 20, Duration: 30 * 24 * time.Hour},
+			{Question: "Will {coin} stay above {price} for next 48 hours?", ChangePercent: -5, Duration: 48 * time.Hour},
+		},
+		Dedup: DedupRules{
+			Enabled:          true,
+			ThresholdPercent: 2,
+			HorizonTolerance: 12 * time.Hour,
+		},
+	}
+}
+
+// LoadRules reads rules from a .yaml, .yml or .toml file on top of DefaultRules
+func LoadRules(path string) (*Rules, error) {
+	rules := DefaultRules()
+	if err := config.DecodeFile(path, rules); err != nil {
+		return nil, err
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Validate reports every invalid rule at once
+func (r *Rules) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(r.CreateInterval > 0, "create_interval must be positive")
+	check(r.ResolveInterval > 0, "resolve_interval must be positive")
+	check(r.MaxActive >= 0, "max_active must not be negative")
+	check(r.HouseLiquidity >= 0, "house_liquidity must not be negative")
+
+	if len(r.Coins.IDs) == 0 {
+		check(r.Coins.Top >= 1 && r.Coins.Top <= maxTopCoins,
+			"coins.top must be between 1 and %d, got %d", maxTopCoins, r.Coins.Top)
+	}
+	for _, id := range r.Coins.IDs {
+		check(strings.TrimSpace(id) != "", "coins.ids must not contain empty IDs")
+	}
+
+	check(len(r.Templates) > 0, "at least one template is required")
+	for i, t := range r.Templates {
+		check(strings.Contains(t.Question, "{coin}") && strings.Contains(t.Question, "{price}"),
+			"templates[%d].question must contain {coin} and {price}", i)
+		check(t.ChangePercent > -100, "templates[%d].change_percent must be above -100", i)
+		check(t.Duration > 0, "templates[%d].duration must be positive", i)
+		check(t.Weight >= 0, "templates[%d].weight must not be negative", i)
+	}
+
+	check(r.Dedup.ThresholdPercent >= 0, "dedup.threshold_percent must not be negative")
+	check(r.Dedup.HorizonTolerance >= 0, "dedup.horizon_tolerance must not be negative")
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid oracle rules: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// pickTemplate chooses a template at random in proportion to its weight
+func (r *Rules) pickTemplate() Template {
+	total := 0
+	for _, t := range r.Templates {
+		total += t.weight()
+	}
+
+	n := rand.Intn(total)
+	for _, t := range r.Templates {
+		if n < t.weight() {
+			return t
+		}
+		n -= t.weight()
+	}
+	return r.Templates[len(r.Templates)-1]
+}
+
+// excluded reports whether the rules never use the coin
+func (c CoinRules) excluded(coin CoinPrice) bool {
+	for _, id := range c.Exclude {
+		if id == coin.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Template) weight() int {
+	if t.Weight == 0 {
+		return 1
+	}
+	return t.Weight
+}
+
+// question fills in the template for a coin and target price
+func (t Template) question(coin CoinPrice, target float64) string {
+	return strings.NewReplacer(
+		"{coin}", coin.Name,
+		"{symbol}", strings.ToUpper(coin.Symbol),
+		"{price}", formatPrice(target),
+	).Replace(t.Question)
+}
+
+// formatPrice shows fewer decimals the larger the price
+func formatPrice(price float64) string {
+	switch {
+	case price >= 1000:
+		return fmt.Sprintf("$%.0f", price)
+	case price >= 1:
+		return fmt.Sprintf("$%.2f", price)
+	default:
+		return fmt.Sprintf("$%.4f", price)
+	}
+}
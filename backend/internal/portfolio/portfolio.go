@@ -0,0 +1,77 @@
+package portfolio
+
+import (
+	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/storage"
+)
+
+// Value computes the cost basis, mark value and P&L of a position.
+//
+// Open markets are marked at the expected payout under the current pool
+// odds. Resolved markets realize their payout (zero for the losing side),
+// and whatever has not been claimed yet is reported as claimable. Cancelled
+// markets are carried at cost.
+func Value(market *models.Market, position *models.UserPosition) *models.PositionValuation {
+	v := &models.PositionValuation{
+		Market:    market,
+		Position:  position,
+		CostBasis: position.YesAmount + position.NoAmount,
+	}
+
+	switch market.Status {
+	case models.StatusResolved:
+		payout := storage.CalculatePayout(market, position)
+		v.RealizedPnL = payout - v.CostBasis
+		if !position.Claimed {
+			v.Claimable = payout
+			v.MarkValue = payout
+		}
+	case models.StatusCancelled:
+		v.MarkValue = v.CostBasis
+	default:
+		totalPool := market.YesPool + market.NoPool
+		odds := models.CalculateOdds(market)
+
+		var payoutIfYes, payoutIfNo float64
+		if position.YesShares > 0 && market.TotalYesShares > 0 {
+			payoutIfYes = totalPool * position.YesShares / market.TotalYesShares
+		}
+		if position.NoShares > 0 && market.TotalNoShares > 0 {
+			payoutIfNo = totalPool * position.NoShares / market.TotalNoShares
+		}
+
+		v.MarkValue = odds.Yes*payoutIfYes + odds.No*payoutIfNo
+		v.UnrealizedPnL = v.MarkValue - v.CostBasis
+	}
+
+	return v
+}
+
+// Build values every position and totals them against the account balance.
+// Positions whose market is missing from markets are skipped.
+func Build(positions []*models.UserPosition, markets map[int]*models.Market, balance float64) *models.Portfolio {
+	p := &models.Portfolio{
+		Positions: make([]*models.PositionValuation, 0, len(positions)),
+	}
+
+	for _, position := range positions {
+		market, ok := markets[position.MarketID]
+		if !ok {
+			continue
+		}
+
+		v := Value(market, position)
+		p.Positions = append(p.Positions, v)
+
+		p.Totals.CostBasis += v.CostBasis
+		p.Totals.MarkValue += v.MarkValue
+		p.Totals.UnrealizedPnL += v.UnrealizedPnL
+		p.Totals.RealizedPnL += v.RealizedPnL
+		p.Totals.Claimable += v.Claimable
+	}
+
+	p.Totals.Balance = balance
+	p.Totals.Equity = balance + p.Totals.MarkValue
+
+	return p
+}
@@ -0,0 +1,66 @@
+// Package search tokenizes market search queries and ranks questions for the
+// backends that have no native full-text search.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxTerms caps the number of words taken from a query
+const MaxTerms = 8
+
+// Terms splits a query into lower-case alphanumeric words. Punctuation is
+// dropped, so the result is safe to embed in a tsquery.
+func Terms(query string) []string {
+	terms := words(query)
+	if len(terms) > MaxTerms {
+		terms = terms[:MaxTerms]
+	}
+	return terms
+}
+
+// TSQuery builds a PostgreSQL to_tsquery expression requiring every term,
+// each matched as a prefix
+func TSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		parts[i] = t + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+// Score ranks text against terms the way the PostgreSQL search does: every
+// term must match the start of some word in text. Exact word matches count
+// more than prefix matches, and matches in shorter texts rank higher. ok is
+// false when a term does not match.
+func Score(text string, terms []string) (score float64, ok bool) {
+	textWords := words(text)
+	if len(terms) == 0 || len(textWords) == 0 {
+		return 0, false
+	}
+
+	for _, term := range terms {
+		best := 0.0
+		for _, w := range textWords {
+			switch {
+			case w == term:
+				best = 1
+			case best < 0.5 && strings.HasPrefix(w, term):
+				best = 0.5
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		score += best
+	}
+
+	return score / float64(len(textWords)), true
+}
+
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/lib/pq"
+)
+
+// IsUnavailable reports whether err means the database could not be reached
+// or was too busy to answer, so the request may succeed if retried
+func IsUnavailable(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// 08: connection exception, 53: insufficient resources, 57: operator intervention
+		switch pqErr.Code.Class() {
+		case "08", "53", "57":
+			return true
+		}
+	}
+
+	return isSQLiteBusy(err)
+}
+
+// IsConflict reports whether err is a unique, foreign key or other integrity
+// constraint violation
+func IsConflict(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Class() == "23"
+	}
+
+	return isSQLiteConstraint(err)
+}
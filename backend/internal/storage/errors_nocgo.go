@@ -0,0 +1,10 @@
+//go:build !cgo
+
+package storage
+
+// Without cgo the SQLite driver cannot open a database, so no error comes
+// from SQLite
+
+func isSQLiteBusy(err error) bool { return false }
+
+func isSQLiteConstraint(err error) bool { return false }
@@ -0,0 +1,27 @@
+//go:build cgo
+
+package storage
+
+import (
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// isSQLiteBusy reports whether err is SQLite failing to take a lock
+func isSQLiteBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+	return false
+}
+
+// isSQLiteConstraint reports whether err is an SQLite constraint violation
+func isSQLiteConstraint(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrConstraint
+	}
+	return false
+}
@@ -1,13 +1,18 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/linera-prediction-market/backend/internal/db"
 	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/search"
 )
 
 // PostgresStorage implements storage using PostgreSQL
@@ -20,80 +25,44 @@ func NewPostgresStorage(database *db.DB) *PostgresStorage {
 	return &PostgresStorage{db: database}
 }
 
+// marketColumns lists the markets columns in the order scanMarket reads them
+const marketColumns = `id, question, category, status, end_time, yes_pool, no_pool,
+		       total_yes_shares, total_no_shares, winning_outcome, resolution_source, created_at`
+
 // GetMarkets retrieves all markets from the database
-func (s *PostgresStorage) GetMarkets() ([]*models.Market, error) {
+func (s *PostgresStorage) GetMarkets(ctx context.Context) ([]*models.Market, error) {
 	query := `
-		SELECT id, question, category, status, end_time, yes_pool, no_pool,
-		       total_yes_shares, total_no_shares, winning_outcome, created_at
+		SELECT ` + marketColumns + `
 		FROM markets
 		ORDER BY end_time ASC
 	`
 
-	rows, err := s.db.Query(query)
+	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query markets: %w", err)
 	}
 	defer rows.Close()
 
-	var markets []*models.Market
-	for rows.Next() {
-		market := &models.Market{}
-		var winningOutcome sql.NullString
-
-		err := rows.Scan(
-			&market.ID,
-			&market.Question,
-			&market.Category,
-			&market.Status,
-			&market.EndTime,
-			&market.YesPool,
-			&market.NoPool,
-			&market.TotalYesShares,
-			&market.TotalNoShares,
-			&winningOutcome,
-			&market.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan market: %w", err)
-		}
-
-		if winningOutcome.Valid {
-			outcome := models.Outcome(winningOutcome.String)
-			market.WinningOutcome = &outcome
-		}
-
-		markets = append(markets, market)
+	markets, err := scanMarkets(rows)
+	if err != nil {
+		return nil, err
 	}
 
+	if err := s.attachTags(ctx, markets); err != nil {
+		return nil, err
+	}
 	return markets, nil
 }
 
 // GetMarket retrieves a single market by ID
-func (s *PostgresStorage) GetMarket(id int) (*models.Market, error) {
+func (s *PostgresStorage) GetMarket(ctx context.Context, id int) (*models.Market, error) {
 	query := `
-		SELECT id, question, category, status, end_time, yes_pool, no_pool,
-		       total_yes_shares, total_no_shares, winning_outcome, created_at
+		SELECT ` + marketColumns + `
 		FROM markets
 		WHERE id = $1
 	`
 
-	market := &models.Market{}
-	var winningOutcome sql.NullString
-
-	err := s.db.QueryRow(query, id).Scan(
-		&market.ID,
-		&market.Question,
-		&market.Category,
-		&market.Status,
-		&market.EndTime,
-		&market.YesPool,
-		&market.NoPool,
-		&market.TotalYesShares,
-		&market.TotalNoShares,
-		&winningOutcome,
-		&market.CreatedAt,
-	)
-
+	market, err := scanMarket(s.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -101,20 +70,81 @@ func (s *PostgresStorage) GetMarket(id int) (*models.Market, error) {
 		return nil, fmt.Errorf("failed to get market: %w", err)
 	}
 
-	if winningOutcome.Valid {
-		outcome := models.Outcome(winningOutcome.String)
-		market.WinningOutcome = &outcome
+	if err := s.attachTags(ctx, []*models.Market{market}); err != nil {
+		return nil, err
+	}
+	return market, nil
+}
+
+// GetMarketsByID retrieves the markets with the given IDs in one query,
+// ordered by ID. IDs with no market are skipped.
+func (s *PostgresStorage) GetMarketsByID(ctx context.Context, ids []int) ([]*models.Market, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		args[i] = id
+	}
+
+	query := `
+		SELECT ` + marketColumns + `
+		FROM markets
+		WHERE id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query markets: %w", err)
+	}
+	defer rows.Close()
+
+	markets, err := scanMarkets(rows)
+	if err != nil {
+		return nil, err
 	}
 
+	if err := s.attachTags(ctx, markets); err != nil {
+		return nil, err
+	}
+	return markets, nil
+}
+
+// GetActiveMarketByQuestion retrieves an active market whose question matches
+// case-insensitively, or nil if there is none
+func (s *PostgresStorage) GetActiveMarketByQuestion(ctx context.Context, question string) (*models.Market, error) {
+	query := `
+		SELECT ` + marketColumns + `
+		FROM markets
+		WHERE status = 'Active' AND LOWER(question) = LOWER($1)
+		ORDER BY id ASC
+		LIMIT 1
+	`
+
+	market, err := scanMarket(s.db.QueryRowContext(ctx, query, question))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market by question: %w", err)
+	}
+
+	if err := s.attachTags(ctx, []*models.Market{market}); err != nil {
+		return nil, err
+	}
 	return market, nil
 }
 
 // SaveMarket inserts or updates a market
-func (s *PostgresStorage) SaveMarket(market *models.Market) error {
+func (s *PostgresStorage) SaveMarket(ctx context.Context, market *models.Market) error {
 	query := `
 		INSERT INTO markets (question, category, status, end_time, yes_pool, no_pool,
-		                     total_yes_shares, total_no_shares, winning_outcome, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		                     total_yes_shares, total_no_shares, winning_outcome, resolution_source, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id
 	`
 
@@ -123,37 +153,47 @@ func (s *PostgresStorage) SaveMarket(market *models.Market) error {
 		s := string(*market.WinningOutcome)
 		winningOutcome = &s
 	}
+	if market.CreatedAt.IsZero() {
+		market.CreatedAt = time.Now()
+	}
 
-	err := s.db.QueryRow(
+	err := s.db.QueryRowContext(ctx,
 		query,
 		market.Question,
 		market.Category,
 		market.Status,
-		market.EndTime,
+		market.EndTime.UTC(),
 		market.YesPool,
 		market.NoPool,
 		market.TotalYesShares,
 		market.TotalNoShares,
 		winningOutcome,
-		market.CreatedAt,
+		market.ResolutionSource,
+		market.CreatedAt.UTC(),
 	).Scan(&market.ID)
 
 	if err != nil {
 		return fmt.Errorf("failed to save market: %w", err)
 	}
 
+	if len(market.Tags) > 0 {
+		if err := s.SetMarketTags(ctx, market.ID, market.Tags); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("💾 Saved market #%d to database: %s", market.ID, market.Question)
 	return nil
 }
 
-// UpdateMarket updates an existing market
-func (s *PostgresStorage) UpdateMarket(market *models.Market) error {
+// UpdateMarket updates an existing market. Tags are changed with SetMarketTags.
+func (s *PostgresStorage) UpdateMarket(ctx context.Context, market *models.Market) error {
 	query := `
 		UPDATE markets
 		SET question = $1, category = $2, status = $3, end_time = $4,
 		    yes_pool = $5, no_pool = $6, total_yes_shares = $7, total_no_shares = $8,
-		    winning_outcome = $9
-		WHERE id = $10
+		    winning_outcome = $9, resolution_source = $10
+		WHERE id = $11
 	`
 
 	var winningOutcome *string
@@ -162,17 +202,18 @@ func (s *PostgresStorage) UpdateMarket(market *models.Market) error {
 		winningOutcome = &s
 	}
 
-	_, err := s.db.Exec(
+	_, err := s.db.ExecContext(ctx,
 		query,
 		market.Question,
 		market.Category,
 		market.Status,
-		market.EndTime,
+		market.EndTime.UTC(),
 		market.YesPool,
 		market.NoPool,
 		market.TotalYesShares,
 		market.TotalNoShares,
 		winningOutcome,
+		market.ResolutionSource,
 		market.ID,
 	)
 
@@ -184,14 +225,14 @@ func (s *PostgresStorage) UpdateMarket(market *models.Market) error {
 }
 
 // GetPositions retrieves all user positions
-func (s *PostgresStorage) GetPositions() ([]*models.UserPosition, error) {
+func (s *PostgresStorage) GetPositions(ctx context.Context) ([]*models.UserPosition, error) {
 	query := `
 		SELECT id, market_id, yes_shares, no_shares, yes_amount, no_amount, claimed
 		FROM user_positions
 		ORDER BY created_at DESC
 	`
 
-	rows, err := s.db.Query(query)
+	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query positions: %w", err)
 	}
@@ -222,7 +263,7 @@ func (s *PostgresStorage) GetPositions() ([]*models.UserPosition, error) {
 }
 
 // GetPosition retrieves a user's position for a specific market
-func (s *PostgresStorage) GetPosition(marketID int) (*models.UserPosition, error) {
+func (s *PostgresStorage) GetPosition(ctx context.Context, marketID int) (*models.UserPosition, error) {
 	query := `
 		SELECT market_id, yes_shares, no_shares, yes_amount, no_amount, claimed
 		FROM user_positions
@@ -230,7 +271,7 @@ func (s *PostgresStorage) GetPosition(marketID int) (*models.UserPosition, error
 	`
 
 	position := &models.UserPosition{}
-	err := s.db.QueryRow(query, marketID).Scan(
+	err := s.db.QueryRowContext(ctx, query, marketID).Scan(
 		&position.MarketID,
 		&position.YesShares,
 		&position.NoShares,
@@ -250,9 +291,9 @@ func (s *PostgresStorage) GetPosition(marketID int) (*models.UserPosition, error
 }
 
 // SavePosition inserts or updates a user position
-func (s *PostgresStorage) SavePosition(position *models.UserPosition) error {
+func (s *PostgresStorage) SavePosition(ctx context.Context, position *models.UserPosition) error {
 	// Check if position exists
-	existing, err := s.GetPosition(position.MarketID)
+	existing, err := s.GetPosition(ctx, position.MarketID)
 	if err != nil {
 		return err
 	}
@@ -263,7 +304,7 @@ func (s *PostgresStorage) SavePosition(position *models.UserPosition) error {
 			INSERT INTO user_positions (market_id, yes_shares, no_shares, yes_amount, no_amount, claimed)
 			VALUES ($1, $2, $3, $4, $5, $6)
 		`
-		_, err = s.db.Exec(query, position.MarketID, position.YesShares, position.NoShares,
+		_, err = s.db.ExecContext(ctx, query, position.MarketID, position.YesShares, position.NoShares,
 			position.YesAmount, position.NoAmount, position.Claimed)
 	} else {
 		// Update existing position
@@ -272,7 +313,7 @@ func (s *PostgresStorage) SavePosition(position *models.UserPosition) error {
 			SET yes_shares = $1, no_shares = $2, yes_amount = $3, no_amount = $4, claimed = $5
 			WHERE market_id = $6
 		`
-		_, err = s.db.Exec(query, position.YesShares, position.NoShares,
+		_, err = s.db.ExecContext(ctx, query, position.YesShares, position.NoShares,
 			position.YesAmount, position.NoAmount, position.Claimed, position.MarketID)
 	}
 
@@ -284,11 +325,11 @@ func (s *PostgresStorage) SavePosition(position *models.UserPosition) error {
 }
 
 // GetBalance retrieves the user's balance
-func (s *PostgresStorage) GetBalance() (float64, error) {
+func (s *PostgresStorage) GetBalance(ctx context.Context) (float64, error) {
 	query := `SELECT balance FROM user_balance WHERE id = 1`
 
 	var balance float64
-	err := s.db.QueryRow(query).Scan(&balance)
+	err := s.db.QueryRowContext(ctx, query).Scan(&balance)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get balance: %w", err)
 	}
@@ -297,14 +338,14 @@ func (s *PostgresStorage) GetBalance() (float64, error) {
 }
 
 // UpdateBalance updates the user's balance
-func (s *PostgresStorage) UpdateBalance(amount float64) error {
+func (s *PostgresStorage) UpdateBalance(ctx context.Context, amount float64) error {
 	query := `
 		UPDATE user_balance
 		SET balance = balance + $1
 		WHERE id = 1
 	`
 
-	_, err := s.db.Exec(query, amount)
+	_, err := s.db.ExecContext(ctx, query, amount)
 	if err != nil {
 		return fmt.Errorf("failed to update balance: %w", err)
 	}
@@ -313,160 +354,764 @@ func (s *PostgresStorage) UpdateBalance(amount float64) error {
 }
 
 // GetExpiredMarkets retrieves markets that have passed their end time but are still active
-func (s *PostgresStorage) GetExpiredMarkets() ([]*models.Market, error) {
+func (s *PostgresStorage) GetExpiredMarkets(ctx context.Context) ([]*models.Market, error) {
 	query := `
-		SELECT id, question, category, status, end_time, yes_pool, no_pool,
-		       total_yes_shares, total_no_shares, winning_outcome, created_at
+		SELECT ` + marketColumns + `
 		FROM markets
 		WHERE status = 'Active' AND end_time < $1
 		ORDER BY end_time ASC
 	`
 
-	rows, err := s.db.Query(query, time.Now())
+	rows, err := s.db.QueryContext(ctx, query, time.Now().UTC())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query expired markets: %w", err)
 	}
 	defer rows.Close()
 
-	var markets []*models.Market
-	for rows.Next() {
-		market := &models.Market{}
-		var winningOutcome sql.NullString
+	markets, err := scanMarkets(rows)
+	if err != nil {
+		return nil, err
+	}
 
-		err := rows.Scan(
-			&market.ID,
-			&market.Question,
-			&market.Category,
-			&market.Status,
-			&market.EndTime,
-			&market.YesPool,
-			&market.NoPool,
-			&market.TotalYesShares,
-			&market.TotalNoShares,
-			&winningOutcome,
-			&market.CreatedAt,
+	if err := s.attachTags(ctx, markets); err != nil {
+		return nil, err
+	}
+	return markets, nil
+}
+
+// marketFilters restricts markets by status ($1), category or any of its
+// sub-categories ($2) and tag ($3); an empty value disables a filter
+const marketFilters = `
+	($1 = '' OR status = $1)
+	AND ($2 = '' OR category = $2 OR category IN (
+		WITH RECURSIVE subcategories(id, name) AS (
+			SELECT id, name FROM categories WHERE name = $2
+			UNION ALL
+			SELECT c.id, c.name FROM categories c JOIN subcategories sc ON c.parent_id = sc.id
 		)
+		SELECT name FROM subcategories
+	))
+	AND ($3 = '' OR id IN (SELECT market_id FROM market_tags WHERE tag = $3))
+`
+
+// marketOrder maps each market sort to its key expression and direction;
+// id breaks ties so the order is total and usable as a keyset
+var marketOrder = map[string]struct{ key, dir string }{
+	models.SortEndingSoon:   {"end_time", "ASC"},
+	models.SortNewest:       {"created_at", "DESC"},
+	models.SortPopular:      {"(yes_pool + no_pool)", "DESC"},
+	models.SortAlphabetical: {"question", "ASC"},
+}
+
+// ListMarkets retrieves a filtered, sorted page of markets. Pages after the
+// first are fetched by keyset: rows that sort after the market q.After.
+func (s *PostgresStorage) ListMarkets(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error) {
+	order, ok := marketOrder[q.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown market sort: %s", q.SortBy)
+	}
+	cmp := ">"
+	if order.dir == "DESC" {
+		cmp = "<"
+	}
+
+	page := &models.MarketPage{}
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM markets WHERE `+marketFilters, q.Status, q.Category, q.Tag).Scan(&page.Total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count markets: %w", err)
+	}
+
+	offset := q.Offset
+	if q.After != 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT ` + marketColumns + `
+		FROM markets
+		WHERE ` + marketFilters + `
+		  AND ($4 = 0 OR (` + order.key + `, id) ` + cmp + ` (SELECT ` + order.key + `, id FROM markets WHERE id = $4))
+		ORDER BY ` + order.key + ` ` + order.dir + `, id ` + order.dir + `
+		LIMIT $5 OFFSET $6
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, q.Status, q.Category, q.Tag, q.After, q.Limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query markets: %w", err)
+	}
+	defer rows.Close()
+
+	page.Markets, err = scanMarkets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachTags(ctx, page.Markets); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// SearchMarkets retrieves the markets whose question matches q.Query using
+// full-text search, best match first. Every word must match, as a prefix.
+func (s *PostgresStorage) SearchMarkets(ctx context.Context, q models.MarketSearch) ([]*models.Market, error) {
+	terms := search.Terms(q.Query)
+	if len(terms) == 0 {
+		return []*models.Market{}, nil
+	}
+
+	query := `
+		SELECT ` + marketColumns + `
+		FROM markets, to_tsquery('simple', $4) AS query
+		WHERE search_vector @@ query
+		  AND ` + marketFilters + `
+		ORDER BY ts_rank(search_vector, query) DESC, end_time ASC, id ASC
+		LIMIT $5
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, q.Status, q.Category, q.Tag, search.TSQuery(terms), q.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search markets: %w", err)
+	}
+	defer rows.Close()
+
+	markets, err := scanMarkets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachTags(ctx, markets); err != nil {
+		return nil, err
+	}
+	return markets, nil
+}
+
+// scanMarket reads one market row selected with marketColumns
+func scanMarket(row rowScanner) (*models.Market, error) {
+	market := &models.Market{}
+	var winningOutcome sql.NullString
+
+	err := row.Scan(
+		&market.ID,
+		&market.Question,
+		&market.Category,
+		&market.Status,
+		&market.EndTime,
+		&market.YesPool,
+		&market.NoPool,
+		&market.TotalYesShares,
+		&market.TotalNoShares,
+		&winningOutcome,
+		&market.ResolutionSource,
+		&market.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if winningOutcome.Valid {
+		outcome := models.Outcome(winningOutcome.String)
+		market.WinningOutcome = &outcome
+	}
+	return market, nil
+}
+
+// scanMarkets reads every market row selected with marketColumns
+func scanMarkets(rows *sql.Rows) ([]*models.Market, error) {
+	markets := []*models.Market{}
+	for rows.Next() {
+		market, err := scanMarket(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan expired market: %w", err)
+			return nil, fmt.Errorf("failed to scan market: %w", err)
 		}
+		markets = append(markets, market)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read markets: %w", err)
+	}
+	return markets, nil
+}
+
+// attachTags loads the tags of every market in one query
+func (s *PostgresStorage) attachTags(ctx context.Context, markets []*models.Market) error {
+	if len(markets) == 0 {
+		return nil
+	}
+
+	byID := make(map[int]*models.Market, len(markets))
+	placeholders := make([]string, len(markets))
+	args := make([]interface{}, len(markets))
+	for i, m := range markets {
+		byID[m.ID] = m
+		m.Tags = nil
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		args[i] = m.ID
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT market_id, tag FROM market_tags
+		WHERE market_id IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY tag ASC
+	`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query market tags: %w", err)
+	}
+	defer rows.Close()
 
-		if winningOutcome.Valid {
-			outcome := models.Outcome(winningOutcome.String)
-			market.WinningOutcome = &outcome
+	for rows.Next() {
+		var marketID int
+		var tag string
+		if err := rows.Scan(&marketID, &tag); err != nil {
+			return fmt.Errorf("failed to scan market tag: %w", err)
 		}
+		if m := byID[marketID]; m != nil {
+			m.Tags = append(m.Tags, tag)
+		}
+	}
 
-		markets = append(markets, market)
+	return rows.Err()
+}
+
+// SetMarketTags replaces the tags of a market
+func (s *PostgresStorage) SetMarketTags(ctx context.Context, marketID int, tags []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tag update: %w", err)
 	}
+	defer tx.Rollback()
 
-	return markets, nil
+	if _, err := tx.ExecContext(ctx, `DELETE FROM market_tags WHERE market_id = $1`, marketID); err != nil {
+		return fmt.Errorf("failed to clear market tags: %w", err)
+	}
+	for _, tag := range tags {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO market_tags (market_id, tag) VALUES ($1, $2)
+			ON CONFLICT (market_id, tag) DO NOTHING
+		`, marketID, tag)
+		if err != nil {
+			return fmt.Errorf("failed to insert market tag: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit market tags: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCategory(row rowScanner) (*models.Category, error) {
+	category := &models.Category{}
+	var parentID sql.NullInt64
+
+	if err := row.Scan(&category.ID, &category.Name, &parentID, &category.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		id := int(parentID.Int64)
+		category.ParentID = &id
+	}
+	return category, nil
+}
+
+// GetCategories retrieves every category ordered by name
+func (s *PostgresStorage) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name, parent_id, created_at FROM categories ORDER BY name ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []*models.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
 }
 
-// InitializeDefaultMarkets inserts the default 6 markets if the database is empty
-func (s *PostgresStorage) InitializeDefaultMarkets() error {
-	// Check if markets exist
-	var count int
-	err := s.db.QueryRow("SELECT COUNT(*) FROM markets").Scan(&count)
+// GetCategory retrieves a category by ID
+func (s *PostgresStorage) GetCategory(ctx context.Context, id int) (*models.Category, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT id, name, parent_id, created_at FROM categories WHERE id = $1`, id)
+
+	category, err := scanCategory(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to count markets: %w", err)
+		return nil, fmt.Errorf("failed to get category: %w", err)
 	}
+	return category, nil
+}
+
+// GetCategoryByName retrieves a category by its unique name
+func (s *PostgresStorage) GetCategoryByName(ctx context.Context, name string) (*models.Category, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT id, name, parent_id, created_at FROM categories WHERE name = $1`, name)
 
-	if count > 0 {
-		log.Println("📊 Markets already exist in database, skipping initialization")
+	category, err := scanCategory(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return category, nil
+}
+
+// SaveCategory inserts a new category and assigns its ID
+func (s *PostgresStorage) SaveCategory(ctx context.Context, category *models.Category) error {
+	if category.CreatedAt.IsZero() {
+		category.CreatedAt = time.Now()
+	}
+
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO categories (name, parent_id, created_at)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, category.Name, category.ParentID, category.CreatedAt.UTC()).Scan(&category.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save category: %w", err)
+	}
+	return nil
+}
+
+// UpdateCategory renames or moves a category. Markets in the category follow
+// a rename, since they refer to it by name.
+func (s *PostgresStorage) UpdateCategory(ctx context.Context, category *models.Category) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin category update: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldName string
+	err = tx.QueryRowContext(ctx, `SELECT name FROM categories WHERE id = $1`, category.ID).Scan(&oldName)
+	if err == sql.ErrNoRows {
 		return nil
 	}
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE categories SET name = $1, parent_id = $2 WHERE id = $3`,
+		category.Name, category.ParentID, category.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
 
-	log.Println("🌱 Initializing default markets in database...")
-
-	now := time.Now()
-	defaultMarkets := []*models.Market{
-		{
-			Question:       "Will Manchester City win the Premier League 2025-26?",
-			Category:       "Sports",
-			Status:         models.StatusActive,
-			EndTime:        time.Date(2026, 5, 23, 20, 0, 0, 0, time.UTC),
-			YesPool:        1250,
-			NoPool:         850,
-			TotalYesShares: 1250000,
-			TotalNoShares:  850000,
-			CreatedAt:      now.Add(-24 * time.Hour),
-		},
-		{
-			Question:       "Will Bitcoin reach $100,000 by December 31, 2025?",
-			Category:       "Crypto",
-			Status:         models.StatusActive,
-			EndTime:        time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
-			YesPool:        3400,
-			NoPool:         2100,
-			TotalYesShares: 3400000,
-			TotalNoShares:  2100000,
-			CreatedAt:      now.Add(-48 * time.Hour),
-		},
-		{
-			Question:       "Will Lakers make it to NBA playoffs this season?",
-			Category:       "Sports",
-			Status:         models.StatusActive,
-			EndTime:        time.Date(2026, 4, 15, 23, 59, 59, 0, time.UTC),
-			YesPool:        890,
-			NoPool:         1560,
-			TotalYesShares: 890000,
-			TotalNoShares:  1560000,
-			CreatedAt:      now.Add(-36 * time.Hour),
-		},
-		{
-			Question:       "Will Ethereum price be above $5,000 by end of November 2025?",
-			Category:       "Crypto",
-			Status:         models.StatusActive,
-			EndTime:        time.Date(2025, 11, 30, 23, 59, 59, 0, time.UTC),
-			YesPool:        2200,
-			NoPool:         1800,
-			TotalYesShares: 2200000,
-			TotalNoShares:  1800000,
-			CreatedAt:      now.Add(-12 * time.Hour),
-		},
-		{
-			Question:       "Will Real Madrid win their next La Liga match?",
-			Category:       "Sports",
-			Status:         models.StatusActive,
-			EndTime:        now.Add(72 * time.Hour),
-			YesPool:        1800,
-			NoPool:         900,
-			TotalYesShares: 1800000,
-			TotalNoShares:  900000,
-			CreatedAt:      now.Add(-6 * time.Hour),
-		},
-		{
-			Question:       "Will Bitcoin price be above $95,000 in 48 hours?",
-			Category:       "Crypto",
-			Status:         models.StatusActive,
-			EndTime:        now.Add(48 * time.Hour),
-			YesPool:        1500,
-			NoPool:         2500,
-			TotalYesShares: 1500000,
-			TotalNoShares:  2500000,
-			CreatedAt:      now.Add(-3 * time.Hour),
-		},
-	}
-
-	for _, market := range defaultMarkets {
-		if err := s.SaveMarket(market); err != nil {
-			return fmt.Errorf("failed to save default market: %w", err)
+	if oldName != category.Name {
+		_, err = tx.ExecContext(ctx, `UPDATE markets SET category = $1 WHERE category = $2`, category.Name, oldName)
+		if err != nil {
+			return fmt.Errorf("failed to rename market category: %w", err)
 		}
 	}
 
-	// Initialize default positions
-	defaultPositions := []*models.UserPosition{
-		{MarketID: 1, YesShares: 50000, YesAmount: 50, Claimed: false},
-		{MarketID: 2, YesShares: 100000, YesAmount: 100, Claimed: false},
-		{MarketID: 6, NoShares: 80000, NoAmount: 80, Claimed: false},
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit category update: %w", err)
+	}
+	return nil
+}
+
+// DeleteCategory removes a category
+func (s *PostgresStorage) DeleteCategory(ctx context.Context, id int) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	return nil
+}
+
+// AppendEvent persists an event and assigns its sequence number
+func (s *PostgresStorage) AppendEvent(ctx context.Context, event *models.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	query := `
+		INSERT INTO events (type, market_id, payload, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING seq
+	`
+
+	err = s.db.QueryRowContext(ctx, query, event.Type, event.MarketID, payload, event.Timestamp).Scan(&event.Seq)
+	if err != nil {
+		return fmt.Errorf("failed to append event: %w", err)
+	}
+
+	return nil
+}
+
+// GetEventsSince retrieves up to limit events with a sequence number greater than afterSeq
+func (s *PostgresStorage) GetEventsSince(ctx context.Context, afterSeq int64, limit int) ([]models.Event, error) {
+	query := `
+		SELECT seq, payload
+		FROM events
+		WHERE seq > $1
+		ORDER BY seq ASC
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, afterSeq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
 	}
+	defer rows.Close()
 
-	for _, position := range defaultPositions {
-		if err := s.SavePosition(position); err != nil {
-			return fmt.Errorf("failed to save default position: %w", err)
+	var events []models.Event
+	for rows.Next() {
+		var seq int64
+		var payload []byte
+
+		if err := rows.Scan(&seq, &payload); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
+
+		var event models.Event
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode event #%d: %w", seq, err)
+		}
+		event.Seq = seq
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// SavePriceSnapshot records a point in a market's price history
+func (s *PostgresStorage) SavePriceSnapshot(ctx context.Context, snapshot *models.PriceSnapshot) error {
+	query := `
+		INSERT INTO market_price_history (market_id, bucket_start, resolution_seconds,
+		                                  open, high, low, close, volume, yes_pool, no_pool)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := s.db.ExecContext(ctx,
+		query,
+		snapshot.MarketID,
+		snapshot.Timestamp,
+		snapshot.Resolution,
+		snapshot.Open,
+		snapshot.High,
+		snapshot.Low,
+		snapshot.Close,
+		snapshot.Volume,
+		snapshot.YesPool,
+		snapshot.NoPool,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save price snapshot: %w", err)
 	}
 
-	log.Printf("✅ Initialized %d default markets and %d positions", len(defaultMarkets), len(defaultPositions))
 	return nil
 }
 
+// GetPriceHistory retrieves a market's price history between from (inclusive) and to (exclusive)
+func (s *PostgresStorage) GetPriceHistory(ctx context.Context, marketID int, from, to time.Time) ([]*models.PriceSnapshot, error) {
+	query := `
+		SELECT market_id, bucket_start, resolution_seconds, open, high, low, close,
+		       volume, yes_pool, no_pool
+		FROM market_price_history
+		WHERE market_id = $1 AND bucket_start >= $2 AND bucket_start < $3
+		ORDER BY bucket_start ASC, id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, marketID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query price history: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*models.PriceSnapshot
+	for rows.Next() {
+		snapshot := &models.PriceSnapshot{}
+
+		err := rows.Scan(
+			&snapshot.MarketID,
+			&snapshot.Timestamp,
+			&snapshot.Resolution,
+			&snapshot.Open,
+			&snapshot.High,
+			&snapshot.Low,
+			&snapshot.Close,
+			&snapshot.Volume,
+			&snapshot.YesPool,
+			&snapshot.NoPool,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price snapshot: %w", err)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// DownsamplePriceHistory merges finer-grained rows older than before into
+// buckets of the given resolution, returning the number of buckets written
+func (s *PostgresStorage) DownsamplePriceHistory(ctx context.Context, before time.Time, resolution time.Duration) (int64, error) {
+	query := `
+		WITH merged AS (
+			DELETE FROM market_price_history
+			WHERE resolution_seconds < $2::int AND bucket_start < $1
+			RETURNING *
+		)
+		INSERT INTO market_price_history (market_id, bucket_start, resolution_seconds,
+		                                  open, high, low, close, volume, yes_pool, no_pool)
+		SELECT market_id,
+		       to_timestamp(floor(extract(epoch FROM bucket_start) / $2::int) * $2::int) AT TIME ZONE 'UTC' AS bucket,
+		       $2::int,
+		       (array_agg(open ORDER BY bucket_start ASC, id ASC))[1],
+		       MAX(high),
+		       MIN(low),
+		       (array_agg(close ORDER BY bucket_start DESC, id DESC))[1],
+		       SUM(volume),
+		       (array_agg(yes_pool ORDER BY bucket_start DESC, id DESC))[1],
+		       (array_agg(no_pool ORDER BY bucket_start DESC, id DESC))[1]
+		FROM merged
+		GROUP BY market_id, bucket
+	`
+
+	result, err := s.db.ExecContext(ctx, query, before, int(resolution.Seconds()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to downsample price history: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// DeletePriceHistoryBefore removes price history older than before
+func (s *PostgresStorage) DeletePriceHistoryBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM market_price_history WHERE bucket_start < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune price history: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// SaveTrade records a single bet and assigns its ID
+func (s *PostgresStorage) SaveTrade(ctx context.Context, trade *models.Trade) error {
+	query := `
+		INSERT INTO trades (user_id, market_id, outcome, amount, shares, price, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	err := s.db.QueryRowContext(ctx,
+		query,
+		trade.User,
+		trade.MarketID,
+		trade.Outcome,
+		trade.Amount,
+		trade.Shares,
+		trade.Price,
+		trade.CreatedAt,
+	).Scan(&trade.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save trade: %w", err)
+	}
+
+	return nil
+}
+
+// GetTrades retrieves a page of trades matching the query, newest first
+func (s *PostgresStorage) GetTrades(ctx context.Context, q models.TradeQuery) ([]*models.Trade, error) {
+	query := `
+		SELECT id, user_id, market_id, outcome, amount, shares, price, created_at
+		FROM trades
+		WHERE ($1 = 0 OR market_id = $1)
+		  AND ($2 = '' OR user_id = $2)
+		  AND ($3 = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, q.MarketID, q.User, q.Before, q.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []*models.Trade
+	for rows.Next() {
+		trade := &models.Trade{}
+
+		err := rows.Scan(
+			&trade.ID,
+			&trade.User,
+			&trade.MarketID,
+			&trade.Outcome,
+			&trade.Amount,
+			&trade.Shares,
+			&trade.Price,
+			&trade.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+
+		trades = append(trades, trade)
+	}
+
+	return trades, nil
+}
+
+// GetTradesSince retrieves every trade placed at or after since, oldest first
+func (s *PostgresStorage) GetTradesSince(ctx context.Context, since time.Time) ([]*models.Trade, error) {
+	query := `
+		SELECT id, user_id, market_id, outcome, amount, shares, price, created_at
+		FROM trades
+		WHERE created_at >= $1
+		ORDER BY id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []*models.Trade
+	for rows.Next() {
+		trade := &models.Trade{}
+
+		err := rows.Scan(
+			&trade.ID,
+			&trade.User,
+			&trade.MarketID,
+			&trade.Outcome,
+			&trade.Amount,
+			&trade.Shares,
+			&trade.Price,
+			&trade.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+
+		trades = append(trades, trade)
+	}
+
+	return trades, nil
+}
+
+// ReplaceLeaderboard atomically swaps the materialized leaderboard rows of a period
+func (s *PostgresStorage) ReplaceLeaderboard(ctx context.Context, period string, entries []*models.LeaderboardEntry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin leaderboard refresh: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM leaderboard WHERE period = $1`, period); err != nil {
+		return fmt.Errorf("failed to clear leaderboard: %w", err)
+	}
+
+	query := `
+		INSERT INTO leaderboard (period, user_id, volume, trades, resolved_markets, wins,
+		                         realized_profit, win_rate, roi, brier_score, refreshed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	for _, e := range entries {
+		_, err := tx.ExecContext(ctx,
+			query,
+			period,
+			e.User,
+			e.Volume,
+			e.Trades,
+			e.ResolvedMarkets,
+			e.Wins,
+			e.RealizedProfit,
+			e.WinRate,
+			e.ROI,
+			e.BrierScore,
+			e.RefreshedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert leaderboard entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit leaderboard refresh: %w", err)
+	}
+
+	return nil
+}
+
+// leaderboardOrder maps leaderboard metrics to their ORDER BY clause
+var leaderboardOrder = map[string]string{
+	"volume":  "volume DESC",
+	"profit":  "realized_profit DESC",
+	"winRate": "win_rate DESC",
+	"roi":     "roi DESC",
+	"brier":   "brier_score ASC NULLS LAST",
+}
+
+// GetLeaderboard retrieves the top entries of a period ranked by metric
+func (s *PostgresStorage) GetLeaderboard(ctx context.Context, period, metric string, limit int) ([]*models.LeaderboardEntry, error) {
+	order, ok := leaderboardOrder[metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown leaderboard metric: %s", metric)
+	}
+
+	query := `
+		SELECT user_id, period, volume, trades, resolved_markets, wins,
+		       realized_profit, win_rate, roi, brier_score, refreshed_at
+		FROM leaderboard
+		WHERE period = $1
+		ORDER BY ` + order + `, user_id ASC
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, period, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.LeaderboardEntry
+	for rows.Next() {
+		entry := &models.LeaderboardEntry{}
+		var brier sql.NullFloat64
+
+		err := rows.Scan(
+			&entry.User,
+			&entry.Period,
+			&entry.Volume,
+			&entry.Trades,
+			&entry.ResolvedMarkets,
+			&entry.Wins,
+			&entry.RealizedProfit,
+			&entry.WinRate,
+			&entry.ROI,
+			&brier,
+			&entry.RefreshedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+
+		if brier.Valid {
+			entry.BrierScore = &brier.Float64
+		}
+		entry.Rank = len(entries) + 1
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/db"
+	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/search"
+)
+
+// SQLiteStorage implements storage on an embedded SQLite database.
+//
+// mattn/go-sqlite3 understands the $N placeholders and RETURNING clauses used
+// by PostgresStorage, so the portable queries are shared and only the
+// PostgreSQL-specific ones are overridden here. Timestamps are always written
+// in UTC, which keeps SQLite's textual time comparisons correct.
+type SQLiteStorage struct {
+	*PostgresStorage
+}
+
+// NewSQLiteStorage creates a new SQLite storage instance
+func NewSQLiteStorage(database *db.DB) *SQLiteStorage {
+	return &SQLiteStorage{PostgresStorage: NewPostgresStorage(database)}
+}
+
+// DownsamplePriceHistory merges finer-grained rows older than before into
+// buckets of the given resolution, returning the number of buckets written
+func (s *SQLiteStorage) DownsamplePriceHistory(ctx context.Context, before time.Time, resolution time.Duration) (int64, error) {
+	seconds := int(resolution.Seconds())
+	before = before.UTC()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin downsampling: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT market_id, bucket_start, resolution_seconds, open, high, low, close,
+		       volume, yes_pool, no_pool
+		FROM market_price_history
+		WHERE resolution_seconds < $1 AND bucket_start < $2
+		ORDER BY bucket_start ASC, id ASC
+	`
+
+	rows, err := tx.QueryContext(ctx, query, seconds, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query price history: %w", err)
+	}
+
+	var snapshots []*models.PriceSnapshot
+	for rows.Next() {
+		snapshot := &models.PriceSnapshot{}
+
+		err := rows.Scan(
+			&snapshot.MarketID,
+			&snapshot.Timestamp,
+			&snapshot.Resolution,
+			&snapshot.Open,
+			&snapshot.High,
+			&snapshot.Low,
+			&snapshot.Close,
+			&snapshot.Volume,
+			&snapshot.YesPool,
+			&snapshot.NoPool,
+		)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan price snapshot: %w", err)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read price history: %w", err)
+	}
+
+	if len(snapshots) == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM market_price_history WHERE resolution_seconds < $1 AND bucket_start < $2`, seconds, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete merged price history: %w", err)
+	}
+
+	buckets := mergeSnapshots(snapshots, resolution)
+	for _, b := range buckets {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO market_price_history (market_id, bucket_start, resolution_seconds,
+			                                  open, high, low, close, volume, yes_pool, no_pool)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`, b.MarketID, b.Timestamp, b.Resolution, b.Open, b.High, b.Low, b.Close, b.Volume, b.YesPool, b.NoPool)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert price bucket: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit downsampling: %w", err)
+	}
+
+	return int64(len(buckets)), nil
+}
+
+// SearchMarkets retrieves the markets whose question matches q.Query, best
+// match first. SQLite has no tsvector, so candidates containing every word are
+// selected with LIKE and ranked with the same rules as the full-text search.
+func (s *SQLiteStorage) SearchMarkets(ctx context.Context, q models.MarketSearch) ([]*models.Market, error) {
+	terms := search.Terms(q.Query)
+	if len(terms) == 0 {
+		return []*models.Market{}, nil
+	}
+
+	query := `
+		SELECT ` + marketColumns + `
+		FROM markets
+		WHERE ` + marketFilters
+	args := []interface{}{q.Status, q.Category, q.Tag}
+	for _, term := range terms {
+		args = append(args, term)
+		// Terms are alphanumeric, so they need no LIKE escaping
+		query += ` AND question LIKE '%' || $` + strconv.Itoa(len(args)) + ` || '%'`
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search markets: %w", err)
+	}
+	defer rows.Close()
+
+	markets, err := scanMarkets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	markets = rankMarkets(markets, terms, q.Limit)
+	if err := s.attachTags(ctx, markets); err != nil {
+		return nil, err
+	}
+	return markets, nil
+}
@@ -1,161 +1,784 @@
 package storage
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/linera-prediction-market/backend/internal/models"
+	"github.com/linera-prediction-market/backend/internal/search"
 )
 
 const InitialShareMultiplier = 1000.0
 
+// DefaultBalance is the starting balance of the demo account
+const DefaultBalance = 10000.0
+
+// Storage is a concurrency-safe in-memory storage backend.
+// It implements the same interfaces as PostgresStorage and hands out copies,
+// so callers can never mutate stored records without saving them.
 type Storage struct {
-	mu            sync.RWMutex
-	Markets       map[int]*models.Market
-	Positions     []*models.UserPosition
-	UserBalance   float64
-	NextMarketID  int
+	mu           sync.RWMutex
+	markets      map[int]*models.Market
+	positions    []*models.UserPosition
+	balance      float64
+	nextMarketID int
+	events       []models.Event
+	history      []*models.PriceSnapshot
+	trades       []*models.Trade
+	leaderboard  map[string][]*models.LeaderboardEntry
+	categories   map[int]*models.Category
+	nextCategory int
 }
 
+// New creates an empty in-memory storage with the default balance and categories
 func New() *Storage {
+	s := &Storage{
+		markets:      make(map[int]*models.Market),
+		balance:      DefaultBalance,
+		nextMarketID: 1,
+		leaderboard:  make(map[string][]*models.LeaderboardEntry),
+		categories:   make(map[int]*models.Category),
+		nextCategory: 1,
+	}
+
+	for _, name := range models.DefaultCategories {
+		s.SaveCategory(context.Background(), &models.Category{Name: name})
+	}
+	return s
+}
+
+func copyMarket(m *models.Market) *models.Market {
+	c := *m
+	if m.WinningOutcome != nil {
+		outcome := *m.WinningOutcome
+		c.WinningOutcome = &outcome
+	}
+	c.Tags = copyTags(m.Tags)
+	return &c
+}
+
+// copyTags returns a sorted, de-duplicated copy of tags (nil when empty)
+func copyTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	c := append([]string(nil), tags...)
+	sort.Strings(c)
+
+	unique := c[:1]
+	for _, t := range c[1:] {
+		if t != unique[len(unique)-1] {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+func copyCategory(c *models.Category) *models.Category {
+	cc := *c
+	if c.ParentID != nil {
+		parent := *c.ParentID
+		cc.ParentID = &parent
+	}
+	return &cc
+}
+
+func copyPosition(p *models.UserPosition) *models.UserPosition {
+	c := *p
+	return &c
+}
+
+// GetMarkets retrieves all markets ordered by end time
+func (s *Storage) GetMarkets(ctx context.Context) ([]*models.Market, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	markets := make([]*models.Market, 0, len(s.markets))
+	for _, m := range s.markets {
+		markets = append(markets, copyMarket(m))
+	}
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].EndTime.Before(markets[j].EndTime)
+	})
+	return markets, nil
+}
+
+// GetMarket retrieves a single market by ID, or nil if it does not exist
+func (s *Storage) GetMarket(ctx context.Context, id int) (*models.Market, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	market, ok := s.markets[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyMarket(market), nil
+}
+
+// GetMarketsByID retrieves the markets with the given IDs, ordered by ID.
+// IDs with no market are skipped.
+func (s *Storage) GetMarketsByID(ctx context.Context, ids []int) ([]*models.Market, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[int]bool, len(ids))
+	var markets []*models.Market
+	for _, id := range ids {
+		if m, ok := s.markets[id]; ok && !seen[id] {
+			seen[id] = true
+			markets = append(markets, copyMarket(m))
+		}
+	}
+	sort.Slice(markets, func(i, j int) bool { return markets[i].ID < markets[j].ID })
+	return markets, nil
+}
+
+// GetActiveMarketByQuestion retrieves an active market whose question matches
+// case-insensitively, or nil if there is none
+func (s *Storage) GetActiveMarketByQuestion(ctx context.Context, question string) (*models.Market, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var found *models.Market
+	for _, m := range s.markets {
+		if m.Status == models.StatusActive && strings.EqualFold(m.Question, question) && (found == nil || m.ID < found.ID) {
+			found = m
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	return copyMarket(found), nil
+}
+
+// SaveMarket inserts a new market and assigns its ID
+func (s *Storage) SaveMarket(ctx context.Context, market *models.Market) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	market.ID = s.nextMarketID
+	s.nextMarketID++
+	if market.CreatedAt.IsZero() {
+		market.CreatedAt = time.Now()
+	}
+	s.markets[market.ID] = copyMarket(market)
+	return nil
+}
+
+// UpdateMarket updates an existing market. Tags are changed with SetMarketTags.
+func (s *Storage) UpdateMarket(ctx context.Context, market *models.Market) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.markets[market.ID]
+	if !ok {
+		return nil
+	}
+
+	updated := copyMarket(market)
+	updated.CreatedAt = existing.CreatedAt
+	updated.Tags = existing.Tags
+	s.markets[market.ID] = updated
+	return nil
+}
+
+// SetMarketTags replaces the tags of a market
+func (s *Storage) SetMarketTags(ctx context.Context, marketID int, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.markets[marketID]; ok {
+		m.Tags = copyTags(tags)
+	}
+	return nil
+}
+
+// matchMarket reports whether a market passes the status, category (with
+// sub-categories) and tag filters; an empty value disables a filter
+func (s *Storage) matchMarket(m *models.Market, status, category, tag string) bool {
+	if status != "" && string(m.Status) != status {
+		return false
+	}
+	if category != "" && m.Category != category && !s.isSubcategory(m.Category, category) {
+		return false
+	}
+	if tag != "" {
+		for _, t := range m.Tags {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// isSubcategory reports whether the category named name sits anywhere below
+// the category named ancestor. Callers must hold s.mu.
+func (s *Storage) isSubcategory(name, ancestor string) bool {
+	var current *models.Category
+	for _, c := range s.categories {
+		if c.Name == name {
+			current = c
+			break
+		}
+	}
+
+	for depth := 0; current != nil && current.ParentID != nil && depth < len(s.categories); depth++ {
+		current = s.categories[*current.ParentID]
+		if current != nil && current.Name == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// GetExpiredMarkets retrieves markets that have passed their end time but are still active
+func (s *Storage) GetExpiredMarkets(ctx context.Context) ([]*models.Market, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	now := time.Now()
+	var markets []*models.Market
+	for _, m := range s.markets {
+		if m.Status == models.StatusActive && m.EndTime.Before(now) {
+			markets = append(markets, copyMarket(m))
+		}
+	}
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].EndTime.Before(markets[j].EndTime)
+	})
+	return markets, nil
+}
+
+// ListMarkets retrieves a filtered, sorted page of markets. Pages after the
+// first start after the market q.After in the requested order.
+func (s *Storage) ListMarkets(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error) {
+	compare, ok := marketCompare[q.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown market sort: %s", q.SortBy)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matched []*models.Market
+	for _, m := range s.markets {
+		if !s.matchMarket(m, q.Status, q.Category, q.Tag) {
+			continue
+		}
+		matched = append(matched, m)
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return compare(matched[i], matched[j]) < 0 })
+	page := &models.MarketPage{Markets: []*models.Market{}, Total: len(matched)}
+
+	start := q.Offset
+	if q.After != 0 {
+		anchor, ok := s.markets[q.After]
+		if !ok {
+			return page, nil
+		}
+		start = sort.Search(len(matched), func(i int) bool { return compare(anchor, matched[i]) < 0 })
+	}
+
+	for i := start; i < len(matched) && len(page.Markets) < q.Limit; i++ {
+		page.Markets = append(page.Markets, copyMarket(matched[i]))
+	}
+	return page, nil
+}
+
+// marketCompare orders markets for each sort, returning a negative number
+// when a comes first. Ties are broken by ID in the sort's direction, matching
+// the SQL backends.
+var marketCompare = map[string]func(a, b *models.Market) int{
+	models.SortEndingSoon: func(a, b *models.Market) int {
+		if c := a.EndTime.Compare(b.EndTime); c != 0 {
+			return c
+		}
+		return a.ID - b.ID
+	},
+	models.SortNewest: func(a, b *models.Market) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return b.ID - a.ID
+	},
+	models.SortPopular: func(a, b *models.Market) int {
+		va, vb := a.YesPool+a.NoPool, b.YesPool+b.NoPool
+		switch {
+		case va > vb:
+			return -1
+		case va < vb:
+			return 1
+		}
+		return b.ID - a.ID
+	},
+	models.SortAlphabetical: func(a, b *models.Market) int {
+		if c := strings.Compare(a.Question, b.Question); c != 0 {
+			return c
+		}
+		return a.ID - b.ID
+	},
+}
+
+// SearchMarkets retrieves the markets whose question matches q.Query, best match first
+func (s *Storage) SearchMarkets(ctx context.Context, q models.MarketSearch) ([]*models.Market, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var candidates []*models.Market
+	for _, m := range s.markets {
+		if !s.matchMarket(m, q.Status, q.Category, q.Tag) {
+			continue
+		}
+		candidates = append(candidates, copyMarket(m))
+	}
+
+	return rankMarkets(candidates, search.Terms(q.Query), q.Limit), nil
+}
+
+// rankMarkets keeps the markets matching every term, ordered by search score,
+// then end time and ID, and returns at most limit of them
+func rankMarkets(markets []*models.Market, terms []string, limit int) []*models.Market {
+	type hit struct {
+		market *models.Market
+		score  float64
+	}
+
+	var hits []hit
+	for _, m := range markets {
+		if score, ok := search.Score(m.Question, terms); ok {
+			hits = append(hits, hit{m, score})
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if !a.market.EndTime.Equal(b.market.EndTime) {
+			return a.market.EndTime.Before(b.market.EndTime)
+		}
+		return a.market.ID < b.market.ID
+	})
+
+	ranked := []*models.Market{}
+	for i := 0; i < len(hits) && i < limit; i++ {
+		ranked = append(ranked, hits[i].market)
+	}
+	return ranked
+}
+
+// GetCategories retrieves every category ordered by name
+func (s *Storage) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	categories := make([]*models.Category, 0, len(s.categories))
+	for _, c := range s.categories {
+		categories = append(categories, copyCategory(c))
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+// GetCategory retrieves a category by ID, or nil if it does not exist
+func (s *Storage) GetCategory(ctx context.Context, id int) (*models.Category, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.categories[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyCategory(c), nil
+}
+
+// GetCategoryByName retrieves a category by its unique name, or nil if it does not exist
+func (s *Storage) GetCategoryByName(ctx context.Context, name string) (*models.Category, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, c := range s.categories {
+		if c.Name == name {
+			return copyCategory(c), nil
+		}
+	}
+	return nil, nil
+}
+
+// SaveCategory inserts a new category and assigns its ID
+func (s *Storage) SaveCategory(ctx context.Context, category *models.Category) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	return &Storage{
-		Markets: map[int]*models.Market{
-			1: {
-				ID:             1,
-				Question:       "Will Manchester City win the Premier League 2025-26?",
-				Category:       "Sports",
-				Status:         models.StatusActive,
-				EndTime:        time.Date(2026, 5, 23, 20, 0, 0, 0, time.UTC),
-				YesPool:        1250,
-				NoPool:         850,
-				TotalYesShares: 1250000,
-				TotalNoShares:  850000,
-				CreatedAt:      now.Add(-24 * time.Hour),
-			},
-			2: {
-				ID:             2,
-				Question:       "Will Bitcoin reach $100,000 by December 31, 2025?",
-				Category:       "Crypto",
-				Status:         models.StatusActive,
-				EndTime:        time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
-				YesPool:        3400,
-				NoPool:         2100,
-				TotalYesShares: 3400000,
-				TotalNoShares:  2100000,
-				CreatedAt:      now.Add(-48 * time.Hour),
-			},
-			3: {
-				ID:             3,
-				Question:       "Will Lakers make it to NBA playoffs this season?",
-				Category:       "Sports",
-				Status:         models.StatusActive,
-				EndTime:        time.Date(2026, 4, 15, 23, 59, 59, 0, time.UTC),
-				YesPool:        890,
-				NoPool:         1560,
-				TotalYesShares: 890000,
-				TotalNoShares:  1560000,
-				CreatedAt:      now.Add(-36 * time.Hour),
-			},
-			4: {
-				ID:             4,
-				Question:       "Will Ethereum price be above $5,000 by end of November 2025?",
-				Category:       "Crypto",
-				Status:         models.StatusActive,
-				EndTime:        time.Date(2025, 11, 30, 23, 59, 59, 0, time.UTC),
-				YesPool:        2200,
-				NoPool:         1800,
-				TotalYesShares: 2200000,
-				TotalNoShares:  1800000,
-				CreatedAt:      now.Add(-12 * time.Hour),
-			},
-			5: {
-				ID:             5,
-				Question:       "Will Real Madrid win their next La Liga match?",
-				Category:       "Sports",
-				Status:         models.StatusActive,
-				EndTime:        now.Add(72 * time.Hour), // 3 days from now
-				YesPool:        1800,
-				NoPool:         900,
-				TotalYesShares: 1800000,
-				TotalNoShares:  900000,
-				CreatedAt:      now.Add(-6 * time.Hour),
-			},
-			6: {
-				ID:              6,
-				Question:        "Will Bitcoin price be above $95,000 in 48 hours?",
-				Category:        "Crypto",
-				Status:          models.StatusActive,
-				EndTime:         now.Add(48 * time.Hour), // 2 days from now
-				YesPool:         1500,
-				NoPool:          2500,
-				TotalYesShares:  1500000,
-				TotalNoShares:   2500000,
-				WinningOutcome:  nil,
-				CreatedAt:       now.Add(-3 * time.Hour),
-			},
-		},
-		Positions: []*models.UserPosition{
-			{MarketID: 1, YesShares: 50000, YesAmount: 50, Claimed: false},
-			{MarketID: 2, YesShares: 100000, YesAmount: 100, Claimed: false},
-			{MarketID: 6, NoShares: 80000, NoAmount: 80, Claimed: false},
-		},
-		UserBalance:  1000,
-		NextMarketID: 7,
-	}
-}
-
-func (s *Storage) GetMarkets() []*models.Market {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	markets := make([]*models.Market, 0, len(s.Markets))
-	for _, m := range s.Markets {
-		markets = append(markets, m)
-	}
-	return markets
-}
-
-func (s *Storage) GetMarket(id int) (*models.Market, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	market, ok := s.Markets[id]
-	return market, ok
-}
-
-func (s *Storage) GetPositions() []*models.UserPosition {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	return s.Positions
-}
-
-func (s *Storage) GetPosition(marketID int) *models.UserPosition {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	for _, p := range s.Positions {
+	for _, c := range s.categories {
+		if c.Name == category.Name {
+			return fmt.Errorf("failed to save category: %q already exists", category.Name)
+		}
+	}
+
+	category.ID = s.nextCategory
+	s.nextCategory++
+	if category.CreatedAt.IsZero() {
+		category.CreatedAt = time.Now()
+	}
+	s.categories[category.ID] = copyCategory(category)
+	return nil
+}
+
+// UpdateCategory renames or moves a category. Markets in the category follow
+// a rename, since they refer to it by name.
+func (s *Storage) UpdateCategory(ctx context.Context, category *models.Category) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.categories[category.ID]
+	if !ok {
+		return nil
+	}
+
+	if existing.Name != category.Name {
+		for _, m := range s.markets {
+			if m.Category == existing.Name {
+				m.Category = category.Name
+			}
+		}
+	}
+
+	updated := copyCategory(category)
+	updated.CreatedAt = existing.CreatedAt
+	s.categories[category.ID] = updated
+	return nil
+}
+
+// DeleteCategory removes a category
+func (s *Storage) DeleteCategory(ctx context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.categories, id)
+	return nil
+}
+
+// GetPositions retrieves all user positions, newest first
+func (s *Storage) GetPositions(ctx context.Context) ([]*models.UserPosition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	positions := make([]*models.UserPosition, 0, len(s.positions))
+	for i := len(s.positions) - 1; i >= 0; i-- {
+		positions = append(positions, copyPosition(s.positions[i]))
+	}
+	return positions, nil
+}
+
+// GetPosition retrieves the position for a market, or nil if there is none
+func (s *Storage) GetPosition(ctx context.Context, marketID int) (*models.UserPosition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range s.positions {
 		if p.MarketID == marketID {
-			return p
+			return copyPosition(p), nil
+		}
+	}
+	return nil, nil
+}
+
+// SavePosition inserts or updates a user position
+func (s *Storage) SavePosition(ctx context.Context, position *models.UserPosition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.markets[position.MarketID]; !ok {
+		return fmt.Errorf("failed to save position: market #%d does not exist", position.MarketID)
+	}
+
+	for i, p := range s.positions {
+		if p.MarketID == position.MarketID {
+			s.positions[i] = copyPosition(position)
+			return nil
+		}
+	}
+	s.positions = append(s.positions, copyPosition(position))
+	return nil
+}
+
+// GetBalance retrieves the user's balance
+func (s *Storage) GetBalance(ctx context.Context) (float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.balance, nil
+}
+
+// UpdateBalance adds amount (which may be negative) to the user's balance
+func (s *Storage) UpdateBalance(ctx context.Context, amount float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.balance += amount
+	return nil
+}
+
+// AppendEvent stores an event and assigns its sequence number
+func (s *Storage) AppendEvent(ctx context.Context, event *models.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event.Seq = int64(len(s.events)) + 1
+	s.events = append(s.events, *event)
+	return nil
+}
+
+// GetEventsSince retrieves up to limit events with a sequence number greater than afterSeq
+func (s *Storage) GetEventsSince(ctx context.Context, afterSeq int64, limit int) ([]models.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if afterSeq < 0 {
+		afterSeq = 0
+	}
+	if afterSeq >= int64(len(s.events)) {
+		return nil, nil
+	}
+
+	// Sequence numbers are dense, so seq N lives at index N-1
+	end := afterSeq + int64(limit)
+	if end > int64(len(s.events)) {
+		end = int64(len(s.events))
+	}
+	events := make([]models.Event, end-afterSeq)
+	copy(events, s.events[afterSeq:end])
+	return events, nil
+}
+
+// SavePriceSnapshot records a point in a market's price history
+func (s *Storage) SavePriceSnapshot(ctx context.Context, snapshot *models.PriceSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := *snapshot
+	s.history = append(s.history, &c)
+	return nil
+}
+
+// GetPriceHistory retrieves a market's price history between from (inclusive) and to (exclusive)
+func (s *Storage) GetPriceHistory(ctx context.Context, marketID int, from, to time.Time) ([]*models.PriceSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var snapshots []*models.PriceSnapshot
+	for _, h := range s.history {
+		if h.MarketID == marketID && !h.Timestamp.Before(from) && h.Timestamp.Before(to) {
+			c := *h
+			snapshots = append(snapshots, &c)
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Timestamp.Before(snapshots[j].Timestamp)
+	})
+	return snapshots, nil
+}
+
+// DownsamplePriceHistory merges finer-grained rows older than before into
+// buckets of the given resolution, returning the number of buckets written
+func (s *Storage) DownsamplePriceHistory(ctx context.Context, before time.Time, resolution time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sort.SliceStable(s.history, func(i, j int) bool {
+		return s.history[i].Timestamp.Before(s.history[j].Timestamp)
+	})
+
+	var kept, merge []*models.PriceSnapshot
+	for _, h := range s.history {
+		if h.Resolution < int(resolution.Seconds()) && h.Timestamp.Before(before) {
+			merge = append(merge, h)
+		} else {
+			kept = append(kept, h)
+		}
+	}
+
+	buckets := mergeSnapshots(merge, resolution)
+	s.history = append(kept, buckets...)
+
+	return int64(len(buckets)), nil
+}
+
+// mergeSnapshots combines snapshots (ordered by timestamp) into one OHLC
+// bucket per market and resolution-aligned interval
+func mergeSnapshots(snapshots []*models.PriceSnapshot, resolution time.Duration) []*models.PriceSnapshot {
+	type key struct {
+		marketID int
+		bucket   int64
+	}
+
+	buckets := make(map[key]*models.PriceSnapshot)
+	var merged []*models.PriceSnapshot
+
+	for _, h := range snapshots {
+		start := h.Timestamp.UTC().Truncate(resolution)
+		k := key{h.MarketID, start.Unix()}
+
+		b, ok := buckets[k]
+		if !ok {
+			c := *h
+			c.Timestamp = start
+			c.Resolution = int(resolution.Seconds())
+			buckets[k] = &c
+			merged = append(merged, &c)
+			continue
 		}
+
+		b.High = math.Max(b.High, h.High)
+		b.Low = math.Min(b.Low, h.Low)
+		b.Close = h.Close
+		b.Volume += h.Volume
+		b.YesPool = h.YesPool
+		b.NoPool = h.NoPool
 	}
+
+	return merged
+}
+
+// DeletePriceHistoryBefore removes price history older than before
+func (s *Storage) DeletePriceHistoryBefore(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kept []*models.PriceSnapshot
+	for _, h := range s.history {
+		if !h.Timestamp.Before(before) {
+			kept = append(kept, h)
+		}
+	}
+	deleted := int64(len(s.history) - len(kept))
+	s.history = kept
+
+	return deleted, nil
+}
+
+// SaveTrade records a single bet and assigns its ID
+func (s *Storage) SaveTrade(ctx context.Context, trade *models.Trade) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trade.ID = int64(len(s.trades)) + 1
+	c := *trade
+	s.trades = append(s.trades, &c)
 	return nil
 }
 
-func (s *Storage) GetBalance() float64 {
+// GetTrades retrieves a page of trades matching the query, newest first
+func (s *Storage) GetTrades(ctx context.Context, q models.TradeQuery) ([]*models.Trade, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.UserBalance
+	var trades []*models.Trade
+	for i := len(s.trades) - 1; i >= 0 && len(trades) < q.Limit; i-- {
+		t := s.trades[i]
+		if q.MarketID != 0 && t.MarketID != q.MarketID {
+			continue
+		}
+		if q.User != "" && t.User != q.User {
+			continue
+		}
+		if q.Before != 0 && t.ID >= q.Before {
+			continue
+		}
+		c := *t
+		trades = append(trades, &c)
+	}
+	return trades, nil
 }
 
-func (s *Storage) UpdateBalance(amount float64) {
+// GetTradesSince retrieves every trade placed at or after since, oldest first
+func (s *Storage) GetTradesSince(ctx context.Context, since time.Time) ([]*models.Trade, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var trades []*models.Trade
+	for _, t := range s.trades {
+		if !t.CreatedAt.Before(since) {
+			c := *t
+			trades = append(trades, &c)
+		}
+	}
+	return trades, nil
+}
+
+// ReplaceLeaderboard swaps the materialized leaderboard rows of a period
+func (s *Storage) ReplaceLeaderboard(ctx context.Context, period string, entries []*models.LeaderboardEntry) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.UserBalance += amount
+	rows := make([]*models.LeaderboardEntry, len(entries))
+	for i, e := range entries {
+		c := *e
+		c.Period = period
+		rows[i] = &c
+	}
+	s.leaderboard[period] = rows
+	return nil
+}
+
+// GetLeaderboard retrieves the top entries of a period ranked by metric
+func (s *Storage) GetLeaderboard(ctx context.Context, period, metric string, limit int) ([]*models.LeaderboardEntry, error) {
+	less, ok := leaderboardLess[metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown leaderboard metric: %s", metric)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]*models.LeaderboardEntry, 0, len(s.leaderboard[period]))
+	for _, e := range s.leaderboard[period] {
+		c := *e
+		entries = append(entries, &c)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		return a.User < b.User
+	})
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	for i, e := range entries {
+		e.Rank = i + 1
+	}
+	return entries, nil
+}
+
+// leaderboardLess orders entries for each metric, best first
+var leaderboardLess = map[string]func(a, b *models.LeaderboardEntry) bool{
+	"volume":  func(a, b *models.LeaderboardEntry) bool { return a.Volume > b.Volume },
+	"profit":  func(a, b *models.LeaderboardEntry) bool { return a.RealizedProfit > b.RealizedProfit },
+	"winRate": func(a, b *models.LeaderboardEntry) bool { return a.WinRate > b.WinRate },
+	"roi":     func(a, b *models.LeaderboardEntry) bool { return a.ROI > b.ROI },
+	"brier": func(a, b *models.LeaderboardEntry) bool {
+		if a.BrierScore == nil || b.BrierScore == nil {
+			return a.BrierScore != nil && b.BrierScore == nil
+		}
+		return *a.BrierScore < *b.BrierScore
+	},
 }
 
 func CalculateShares(currentPool, totalShares, betAmount float64) float64 {
@@ -165,3 +788,18 @@ func CalculateShares(currentPool, totalShares, betAmount float64) float64 {
 	return (betAmount * totalShares) / currentPool
 }
 
+// CalculatePayout returns what a position receives from a resolved market
+func CalculatePayout(market *models.Market, position *models.UserPosition) float64 {
+	if market.WinningOutcome == nil {
+		return 0
+	}
+
+	totalPool := market.YesPool + market.NoPool
+
+	if *market.WinningOutcome == models.OutcomeYes && position.YesShares > 0 {
+		return math.Floor((totalPool * position.YesShares) / market.TotalYesShares)
+	} else if *market.WinningOutcome == models.OutcomeNo && position.NoShares > 0 {
+		return math.Floor((totalPool * position.NoShares) / market.TotalNoShares)
+	}
+	return 0
+}
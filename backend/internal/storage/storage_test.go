@@ -0,0 +1,87 @@
+package storage_test
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/db"
+	"github.com/linera-prediction-market/backend/internal/storage"
+	"github.com/linera-prediction-market/backend/internal/storage/storagetest"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Storage {
+		return storage.New()
+	})
+}
+
+func TestSQLiteStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Storage {
+		database, err := db.ConnectSQLite(filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("failed to connect to SQLite: %v", err)
+		}
+		t.Cleanup(func() { database.Close() })
+
+		if err := database.Migrate(); err != nil {
+			t.Fatalf("failed to migrate SQLite: %v", err)
+		}
+		return storage.NewSQLiteStorage(database)
+	})
+}
+
+// TestPostgresStorage runs against the PostgreSQL URL in TEST_DATABASE_URL,
+// giving every test a fresh schema that is dropped afterwards
+func TestPostgresStorage(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := db.Connect(databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to PostgreSQL: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	n := 0
+	storagetest.Run(t, func(t *testing.T) storagetest.Storage {
+		n++
+		schema := fmt.Sprintf("storagetest_%d_%d", time.Now().UnixNano(), n)
+		if _, err := admin.Exec(`CREATE SCHEMA ` + schema); err != nil {
+			t.Fatalf("failed to create schema: %v", err)
+		}
+		t.Cleanup(func() { admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+		database, err := db.Connect(withSearchPath(t, databaseURL, schema))
+		if err != nil {
+			t.Fatalf("failed to connect to PostgreSQL: %v", err)
+		}
+		t.Cleanup(func() { database.Close() })
+
+		if err := database.Migrate(); err != nil {
+			t.Fatalf("failed to migrate PostgreSQL: %v", err)
+		}
+		return storage.NewPostgresStorage(database)
+	})
+}
+
+// withSearchPath points every connection made with databaseURL at schema
+func withSearchPath(t *testing.T, databaseURL, schema string) string {
+	if !strings.HasPrefix(databaseURL, "postgres://") && !strings.HasPrefix(databaseURL, "postgresql://") {
+		return databaseURL + " search_path=" + schema
+	}
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		t.Fatalf("invalid TEST_DATABASE_URL: %v", err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,875 @@
+// Package storagetest is a conformance suite shared by every storage backend.
+//
+// A backend's test calls Run with a constructor that returns a fresh, empty
+// store (migrated, with the default balance and categories and no markets):
+//
+//	func TestSQLiteStorage(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storagetest.Storage {
+//			database, err := db.ConnectSQLite(":memory:")
+//			...
+//			return storage.NewSQLiteStorage(database)
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/fixtures"
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// Storage is the full set of operations every backend must implement
+type Storage interface {
+	GetMarkets(ctx context.Context) ([]*models.Market, error)
+	ListMarkets(ctx context.Context, q models.MarketQuery) (*models.MarketPage, error)
+	SearchMarkets(ctx context.Context, q models.MarketSearch) ([]*models.Market, error)
+	GetMarket(ctx context.Context, id int) (*models.Market, error)
+	GetMarketsByID(ctx context.Context, ids []int) ([]*models.Market, error)
+	SaveMarket(ctx context.Context, market *models.Market) error
+	UpdateMarket(ctx context.Context, market *models.Market) error
+	SetMarketTags(ctx context.Context, marketID int, tags []string) error
+	GetCategories(ctx context.Context) ([]*models.Category, error)
+	GetCategory(ctx context.Context, id int) (*models.Category, error)
+	GetCategoryByName(ctx context.Context, name string) (*models.Category, error)
+	SaveCategory(ctx context.Context, category *models.Category) error
+	UpdateCategory(ctx context.Context, category *models.Category) error
+	DeleteCategory(ctx context.Context, id int) error
+	GetExpiredMarkets(ctx context.Context) ([]*models.Market, error)
+	GetActiveMarketByQuestion(ctx context.Context, question string) (*models.Market, error)
+	GetPositions(ctx context.Context) ([]*models.UserPosition, error)
+	GetPosition(ctx context.Context, marketID int) (*models.UserPosition, error)
+	SavePosition(ctx context.Context, position *models.UserPosition) error
+	GetBalance(ctx context.Context) (float64, error)
+	UpdateBalance(ctx context.Context, amount float64) error
+	AppendEvent(ctx context.Context, event *models.Event) error
+	GetEventsSince(ctx context.Context, afterSeq int64, limit int) ([]models.Event, error)
+	SavePriceSnapshot(ctx context.Context, snapshot *models.PriceSnapshot) error
+	GetPriceHistory(ctx context.Context, marketID int, from, to time.Time) ([]*models.PriceSnapshot, error)
+	DownsamplePriceHistory(ctx context.Context, before time.Time, resolution time.Duration) (int64, error)
+	DeletePriceHistoryBefore(ctx context.Context, before time.Time) (int64, error)
+	SaveTrade(ctx context.Context, trade *models.Trade) error
+	GetTrades(ctx context.Context, q models.TradeQuery) ([]*models.Trade, error)
+	GetTradesSince(ctx context.Context, since time.Time) ([]*models.Trade, error)
+	ReplaceLeaderboard(ctx context.Context, period string, entries []*models.LeaderboardEntry) error
+	GetLeaderboard(ctx context.Context, period, metric string, limit int) ([]*models.LeaderboardEntry, error)
+}
+
+// Run executes the conformance suite, calling newStorage once per subtest
+func Run(t *testing.T, newStorage func(t *testing.T) Storage) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s Storage)
+	}{
+		{"Markets", testMarkets},
+		{"WinningOutcome", testWinningOutcome},
+		{"ExpiredMarkets", testExpiredMarkets},
+		{"ActiveMarketByQuestion", testActiveMarketByQuestion},
+		{"ListMarkets", testListMarkets},
+		{"SearchMarkets", testSearchMarkets},
+		{"Categories", testCategories},
+		{"Tags", testTags},
+		{"Positions", testPositions},
+		{"Balance", testBalance},
+		{"Events", testEvents},
+		{"PriceHistory", testPriceHistory},
+		{"Downsample", testDownsample},
+		{"Trades", testTrades},
+		{"Leaderboard", testLeaderboard},
+		{"SeedFixtures", testSeedFixtures},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStorage(t))
+		})
+	}
+}
+
+// base is a fixed, second-aligned instant so round trips compare exactly
+var base = time.Date(2030, 1, 2, 15, 0, 0, 0, time.UTC)
+
+var ctx = context.Background()
+
+func newMarket(question string, endTime time.Time) *models.Market {
+	return &models.Market{
+		Question: question,
+		Category: "Crypto",
+		Status:   models.StatusActive,
+		EndTime:  endTime,
+		YesPool:  100,
+		NoPool:   50.25,
+	}
+}
+
+func mustSaveMarket(t *testing.T, s Storage, m *models.Market) *models.Market {
+	t.Helper()
+	if err := s.SaveMarket(ctx, m); err != nil {
+		t.Fatalf("SaveMarket: %v", err)
+	}
+	return m
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func testMarkets(t *testing.T, s Storage) {
+	first := newMarket("First?", base.Add(2*time.Hour))
+	first.ResolutionSource = "https://example.com/results"
+	mustSaveMarket(t, s, first)
+	second := mustSaveMarket(t, s, newMarket("Second?", base.Add(time.Hour)))
+
+	if first.ID == 0 || second.ID <= first.ID {
+		t.Fatalf("expected increasing auto-assigned IDs, got %d and %d", first.ID, second.ID)
+	}
+	if first.CreatedAt.IsZero() {
+		t.Errorf("expected SaveMarket to set CreatedAt")
+	}
+
+	got, err := s.GetMarket(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("GetMarket: %v", err)
+	}
+	if got == nil {
+		t.Fatalf("GetMarket(%d) returned nil", first.ID)
+	}
+	if got.Question != "First?" || got.Category != "Crypto" || got.Status != models.StatusActive {
+		t.Errorf("unexpected market: %+v", got)
+	}
+	if got.ResolutionSource != "https://example.com/results" {
+		t.Errorf("ResolutionSource = %q, want https://example.com/results", got.ResolutionSource)
+	}
+	if !got.EndTime.Equal(first.EndTime) {
+		t.Errorf("EndTime = %s, want %s", got.EndTime, first.EndTime)
+	}
+	if !approx(got.YesPool, 100) || !approx(got.NoPool, 50.25) {
+		t.Errorf("pools = %v/%v, want 100/50.25", got.YesPool, got.NoPool)
+	}
+
+	missing, err := s.GetMarket(ctx, first.ID+1000)
+	if err != nil || missing != nil {
+		t.Errorf("GetMarket(missing) = %v, %v; want nil, nil", missing, err)
+	}
+
+	markets, err := s.GetMarkets(ctx)
+	if err != nil {
+		t.Fatalf("GetMarkets: %v", err)
+	}
+	if len(markets) != 2 || markets[0].ID != second.ID || markets[1].ID != first.ID {
+		t.Errorf("expected markets ordered by end time")
+	}
+
+	byID, err := s.GetMarketsByID(ctx, []int{second.ID, first.ID + 1000, first.ID, second.ID})
+	if err != nil {
+		t.Fatalf("GetMarketsByID: %v", err)
+	}
+	if len(byID) != 2 || byID[0].ID != first.ID || byID[1].ID != second.ID {
+		t.Errorf("GetMarketsByID = %+v, want markets %d and %d once each", byID, first.ID, second.ID)
+	}
+	if none, err := s.GetMarketsByID(ctx, nil); err != nil || len(none) != 0 {
+		t.Errorf("GetMarketsByID(nil) = %v, %v; want no markets", none, err)
+	}
+
+	got.Status = models.StatusLocked
+	got.YesPool = 150.5
+	if err := s.UpdateMarket(ctx, got); err != nil {
+		t.Fatalf("UpdateMarket: %v", err)
+	}
+	updated, _ := s.GetMarket(ctx, first.ID)
+	if updated.Status != models.StatusLocked || !approx(updated.YesPool, 150.5) {
+		t.Errorf("update not persisted: %+v", updated)
+	}
+}
+
+func testWinningOutcome(t *testing.T, s Storage) {
+	m := mustSaveMarket(t, s, newMarket("Resolves?", base))
+
+	got, _ := s.GetMarket(ctx, m.ID)
+	if got.WinningOutcome != nil {
+		t.Fatalf("new market has WinningOutcome %q, want nil", *got.WinningOutcome)
+	}
+
+	outcome := models.OutcomeNo
+	got.Status = models.StatusResolved
+	got.WinningOutcome = &outcome
+	if err := s.UpdateMarket(ctx, got); err != nil {
+		t.Fatalf("UpdateMarket: %v", err)
+	}
+
+	resolved, _ := s.GetMarket(ctx, m.ID)
+	if resolved.WinningOutcome == nil || *resolved.WinningOutcome != models.OutcomeNo {
+		t.Errorf("WinningOutcome = %v, want No", resolved.WinningOutcome)
+	}
+
+	// Mutating the returned value must not leak into storage
+	*resolved.WinningOutcome = models.OutcomeYes
+	again, _ := s.GetMarket(ctx, m.ID)
+	if *again.WinningOutcome != models.OutcomeNo {
+		t.Errorf("stored WinningOutcome changed through a returned pointer")
+	}
+}
+
+func testExpiredMarkets(t *testing.T, s Storage) {
+	now := time.Now()
+	older := mustSaveMarket(t, s, newMarket("Older?", now.Add(-2*time.Hour)))
+	newer := mustSaveMarket(t, s, newMarket("Newer?", now.Add(-time.Hour)))
+	mustSaveMarket(t, s, newMarket("Future?", now.Add(time.Hour)))
+
+	locked := newMarket("Locked?", now.Add(-3*time.Hour))
+	locked.Status = models.StatusLocked
+	mustSaveMarket(t, s, locked)
+
+	expired, err := s.GetExpiredMarkets(ctx)
+	if err != nil {
+		t.Fatalf("GetExpiredMarkets: %v", err)
+	}
+	if len(expired) != 2 || expired[0].ID != older.ID || expired[1].ID != newer.ID {
+		var ids []int
+		for _, m := range expired {
+			ids = append(ids, m.ID)
+		}
+		t.Errorf("expired = %v, want [%d %d]", ids, older.ID, newer.ID)
+	}
+}
+
+func testActiveMarketByQuestion(t *testing.T, s Storage) {
+	resolved := newMarket("Will it rain tomorrow?", base)
+	resolved.Status = models.StatusResolved
+	mustSaveMarket(t, s, resolved)
+
+	if got, err := s.GetActiveMarketByQuestion(ctx, "Will it rain tomorrow?"); err != nil || got != nil {
+		t.Errorf("GetActiveMarketByQuestion(resolved) = %v, %v; want nil, nil", got, err)
+	}
+
+	active := mustSaveMarket(t, s, newMarket("Will it rain tomorrow?", base))
+	got, err := s.GetActiveMarketByQuestion(ctx, "will IT rain tomorrow?")
+	if err != nil {
+		t.Fatalf("GetActiveMarketByQuestion: %v", err)
+	}
+	if got == nil || got.ID != active.ID {
+		t.Errorf("GetActiveMarketByQuestion = %+v, want market #%d", got, active.ID)
+	}
+}
+
+func testListMarkets(t *testing.T, s Storage) {
+	specs := []struct {
+		question string
+		category string
+		status   models.MarketStatus
+		endIn    time.Duration
+		volume   float64
+	}{
+		{"Charlie?", "Crypto", models.StatusActive, 3 * time.Hour, 300},
+		{"Alpha?", "Sports", models.StatusActive, time.Hour, 100},
+		{"Echo?", "Crypto", models.StatusResolved, 5 * time.Hour, 100},
+		{"Bravo?", "Crypto", models.StatusActive, 2 * time.Hour, 500},
+		{"Delta?", "Crypto", models.StatusActive, 2 * time.Hour, 200},
+	}
+	for i, spec := range specs {
+		m := newMarket(spec.question, base.Add(spec.endIn))
+		m.Category = spec.category
+		m.Status = spec.status
+		m.YesPool, m.NoPool = spec.volume/2, spec.volume/2
+		m.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		mustSaveMarket(t, s, m)
+	}
+
+	order := map[string][]string{
+		models.SortEndingSoon:   {"Alpha?", "Bravo?", "Delta?", "Charlie?", "Echo?"},
+		models.SortNewest:       {"Delta?", "Bravo?", "Echo?", "Alpha?", "Charlie?"},
+		models.SortPopular:      {"Bravo?", "Charlie?", "Delta?", "Echo?", "Alpha?"},
+		models.SortAlphabetical: {"Alpha?", "Bravo?", "Charlie?", "Delta?", "Echo?"},
+	}
+	for sortBy, want := range order {
+		// Walk the whole list two at a time through the keyset cursor
+		var got []string
+		after := 0
+		for len(got) < len(want)+1 {
+			page, err := s.ListMarkets(ctx, models.MarketQuery{SortBy: sortBy, After: after, Limit: 2})
+			if err != nil {
+				t.Fatalf("ListMarkets(%s): %v", sortBy, err)
+			}
+			if page.Total != len(want) {
+				t.Errorf("%s: Total = %d, want %d", sortBy, page.Total, len(want))
+			}
+			if len(page.Markets) == 0 {
+				break
+			}
+			for _, m := range page.Markets {
+				got = append(got, m.Question)
+			}
+			after = page.Markets[len(page.Markets)-1].ID
+		}
+		if !equalStrings(got, want) {
+			t.Errorf("%s: got %v, want %v", sortBy, got, want)
+		}
+
+		// Offset pagination agrees with the keyset
+		page, _ := s.ListMarkets(ctx, models.MarketQuery{SortBy: sortBy, Offset: 2, Limit: 2})
+		if len(page.Markets) != 2 || page.Markets[0].Question != want[2] || page.Markets[1].Question != want[3] {
+			t.Errorf("%s: offset page did not match the keyset order", sortBy)
+		}
+	}
+
+	filtered, err := s.ListMarkets(ctx, models.MarketQuery{
+		Status:   string(models.StatusActive),
+		Category: "Crypto",
+		SortBy:   models.SortEndingSoon,
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatalf("ListMarkets (filtered): %v", err)
+	}
+	var questions []string
+	for _, m := range filtered.Markets {
+		questions = append(questions, m.Question)
+	}
+	if filtered.Total != 3 || !equalStrings(questions, []string{"Bravo?", "Delta?", "Charlie?"}) {
+		t.Errorf("filtered = %v (total %d), want [Bravo? Delta? Charlie?] (total 3)", questions, filtered.Total)
+	}
+
+	if _, err := s.ListMarkets(ctx, models.MarketQuery{SortBy: "bogus", Limit: 10}); err == nil {
+		t.Errorf("expected an error for an unknown sort")
+	}
+}
+
+func testSearchMarkets(t *testing.T, s Storage) {
+	for _, spec := range []struct {
+		question string
+		category string
+		status   models.MarketStatus
+	}{
+		{"Will Bitcoin reach $100,000 by December?", "Crypto", models.StatusActive},
+		{"Will Bitcoin price be above $95,000 in 48 hours?", "Crypto", models.StatusResolved},
+		{"Will Ethereum flip Bitcoin?", "Crypto", models.StatusActive},
+		{"Will the Lakers make the playoffs?", "Sports", models.StatusActive},
+	} {
+		m := newMarket(spec.question, base)
+		m.Category = spec.category
+		m.Status = spec.status
+		mustSaveMarket(t, s, m)
+	}
+
+	questions := func(q models.MarketSearch) []string {
+		t.Helper()
+		if q.Limit == 0 {
+			q.Limit = 10
+		}
+		markets, err := s.SearchMarkets(ctx, q)
+		if err != nil {
+			t.Fatalf("SearchMarkets(%q): %v", q.Query, err)
+		}
+		if markets == nil {
+			t.Fatalf("SearchMarkets(%q) returned nil, want an empty slice", q.Query)
+		}
+		var got []string
+		for _, m := range markets {
+			got = append(got, m.Question)
+		}
+		return got
+	}
+
+	if got := questions(models.MarketSearch{Query: "bitcoin"}); len(got) != 3 {
+		t.Errorf("bitcoin matched %v, want 3 markets", got)
+	}
+	if got := questions(models.MarketSearch{Query: "BITCOIN reach"}); len(got) != 1 || got[0] != "Will Bitcoin reach $100,000 by December?" {
+		t.Errorf("every word must match, case-insensitively: got %v", got)
+	}
+	if got := questions(models.MarketSearch{Query: "lak"}); len(got) != 1 || got[0] != "Will the Lakers make the playoffs?" {
+		t.Errorf("prefix search got %v", got)
+	}
+	if got := questions(models.MarketSearch{Query: "bitcoin", Status: string(models.StatusResolved)}); len(got) != 1 {
+		t.Errorf("status filter got %v", got)
+	}
+	if got := questions(models.MarketSearch{Query: "make", Category: "Sports"}); len(got) != 1 {
+		t.Errorf("category filter got %v", got)
+	}
+	if got := questions(models.MarketSearch{Query: "bitcoin", Limit: 2}); len(got) != 2 {
+		t.Errorf("limit not honoured: got %v", got)
+	}
+	// Every backend matches words as written: no stopwords, no stemming
+	if got := questions(models.MarketSearch{Query: "will"}); len(got) != 4 {
+		t.Errorf("will matched %v, want all 4 markets", got)
+	}
+	if got := questions(models.MarketSearch{Query: "the lakers"}); len(got) != 1 {
+		t.Errorf("the lakers matched %v, want 1 market", got)
+	}
+	if got := questions(models.MarketSearch{Query: "reaching"}); len(got) != 0 {
+		t.Errorf("reaching matched %v, want no stemmed matches", got)
+	}
+	if got := questions(models.MarketSearch{Query: "dogecoin"}); len(got) != 0 {
+		t.Errorf("dogecoin matched %v", got)
+	}
+	if got := questions(models.MarketSearch{Query: "?!"}); len(got) != 0 {
+		t.Errorf("a query without words matched %v", got)
+	}
+}
+
+func testCategories(t *testing.T, s Storage) {
+	categories, err := s.GetCategories(ctx)
+	if err != nil {
+		t.Fatalf("GetCategories: %v", err)
+	}
+	if len(categories) != len(models.DefaultCategories) {
+		t.Fatalf("got %d categories, want the %d defaults", len(categories), len(models.DefaultCategories))
+	}
+
+	sports, err := s.GetCategoryByName(ctx, "Sports")
+	if err != nil || sports == nil {
+		t.Fatalf("GetCategoryByName(Sports) = %v, %v", sports, err)
+	}
+	if missing, err := s.GetCategoryByName(ctx, "Politics"); err != nil || missing != nil {
+		t.Errorf("GetCategoryByName(missing) = %v, %v; want nil, nil", missing, err)
+	}
+
+	football := &models.Category{Name: "Football", ParentID: &sports.ID}
+	if err := s.SaveCategory(ctx, football); err != nil {
+		t.Fatalf("SaveCategory: %v", err)
+	}
+	premier := &models.Category{Name: "Premier League", ParentID: &football.ID}
+	if err := s.SaveCategory(ctx, premier); err != nil {
+		t.Fatalf("SaveCategory: %v", err)
+	}
+	if football.ID == 0 || premier.ID <= football.ID {
+		t.Fatalf("expected increasing category IDs, got %d and %d", football.ID, premier.ID)
+	}
+
+	got, err := s.GetCategory(ctx, premier.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetCategory = %v, %v", got, err)
+	}
+	if got.Name != "Premier League" || got.ParentID == nil || *got.ParentID != football.ID {
+		t.Errorf("unexpected category: %+v", got)
+	}
+
+	// Markets in a sub-category are listed under every ancestor
+	for _, spec := range []struct{ question, category string }{
+		{"City?", "Premier League"},
+		{"Derby?", "Football"},
+		{"Lakers?", "Sports"},
+		{"Bitcoin?", "Crypto"},
+	} {
+		m := newMarket(spec.question, base)
+		m.Category = spec.category
+		mustSaveMarket(t, s, m)
+	}
+	for category, want := range map[string]int{"Sports": 3, "Football": 2, "Premier League": 1, "Crypto": 1} {
+		page, err := s.ListMarkets(ctx, models.MarketQuery{Category: category, SortBy: models.SortEndingSoon, Limit: 10})
+		if err != nil {
+			t.Fatalf("ListMarkets(%s): %v", category, err)
+		}
+		if page.Total != want || len(page.Markets) != want {
+			t.Errorf("category %s listed %d markets (total %d), want %d", category, len(page.Markets), page.Total, want)
+		}
+	}
+	if found, _ := s.SearchMarkets(ctx, models.MarketSearch{Query: "city", Category: "Sports", Limit: 10}); len(found) != 1 {
+		t.Errorf("search in a parent category found %d markets, want 1", len(found))
+	}
+
+	// Renaming a category carries its markets along
+	football.Name = "Soccer"
+	football.ParentID = nil
+	if err := s.UpdateCategory(ctx, football); err != nil {
+		t.Fatalf("UpdateCategory: %v", err)
+	}
+	if page, _ := s.ListMarkets(ctx, models.MarketQuery{Category: "Soccer", SortBy: models.SortEndingSoon, Limit: 10}); page.Total != 2 {
+		t.Errorf("renamed category lists %d markets, want 2", page.Total)
+	}
+	if page, _ := s.ListMarkets(ctx, models.MarketQuery{Category: "Sports", SortBy: models.SortEndingSoon, Limit: 10}); page.Total != 1 {
+		t.Errorf("moved category is still listed under its old parent")
+	}
+	moved, _ := s.GetCategory(ctx, football.ID)
+	if moved.Name != "Soccer" || moved.ParentID != nil {
+		t.Errorf("update not persisted: %+v", moved)
+	}
+
+	if err := s.DeleteCategory(ctx, premier.ID); err != nil {
+		t.Fatalf("DeleteCategory: %v", err)
+	}
+	if deleted, _ := s.GetCategory(ctx, premier.ID); deleted != nil {
+		t.Errorf("category still exists after DeleteCategory")
+	}
+}
+
+func testTags(t *testing.T, s Storage) {
+	tagged := newMarket("Tagged?", base)
+	tagged.Tags = []string{"halving", "bitcoin"}
+	mustSaveMarket(t, s, tagged)
+	plain := mustSaveMarket(t, s, newMarket("Plain?", base.Add(time.Hour)))
+
+	got, _ := s.GetMarket(ctx, tagged.ID)
+	if !equalStrings(got.Tags, []string{"bitcoin", "halving"}) {
+		t.Errorf("tags = %v, want [bitcoin halving]", got.Tags)
+	}
+
+	// UpdateMarket leaves tags alone
+	got.Tags = nil
+	got.YesPool = 1
+	s.UpdateMarket(ctx, got)
+	if again, _ := s.GetMarket(ctx, tagged.ID); len(again.Tags) != 2 {
+		t.Errorf("UpdateMarket changed tags to %v", again.Tags)
+	}
+
+	if err := s.SetMarketTags(ctx, plain.ID, []string{"etf", "bitcoin"}); err != nil {
+		t.Fatalf("SetMarketTags: %v", err)
+	}
+	if err := s.SetMarketTags(ctx, tagged.ID, []string{"halving"}); err != nil {
+		t.Fatalf("SetMarketTags: %v", err)
+	}
+
+	for tag, want := range map[string][]string{
+		"bitcoin": {"Plain?"},
+		"halving": {"Tagged?"},
+		"etf":     {"Plain?"},
+		"none":    nil,
+	} {
+		page, err := s.ListMarkets(ctx, models.MarketQuery{Tag: tag, SortBy: models.SortEndingSoon, Limit: 10})
+		if err != nil {
+			t.Fatalf("ListMarkets(tag=%s): %v", tag, err)
+		}
+		var questions []string
+		for _, m := range page.Markets {
+			questions = append(questions, m.Question)
+		}
+		if !equalStrings(questions, want) || page.Total != len(want) {
+			t.Errorf("tag %s listed %v, want %v", tag, questions, want)
+		}
+	}
+
+	markets, _ := s.GetMarkets(ctx)
+	for _, m := range markets {
+		if m.ID == plain.ID && !equalStrings(m.Tags, []string{"bitcoin", "etf"}) {
+			t.Errorf("GetMarkets returned tags %v, want [bitcoin etf]", m.Tags)
+		}
+	}
+	if found, _ := s.SearchMarkets(ctx, models.MarketSearch{Query: "plain", Tag: "etf", Limit: 10}); len(found) != 1 || len(found[0].Tags) != 2 {
+		t.Errorf("search with a tag filter returned %+v", found)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func testPositions(t *testing.T, s Storage) {
+	m := mustSaveMarket(t, s, newMarket("Position?", base))
+
+	if p, err := s.GetPosition(ctx, m.ID); err != nil || p != nil {
+		t.Fatalf("GetPosition(empty) = %v, %v; want nil, nil", p, err)
+	}
+
+	if err := s.SavePosition(ctx, &models.UserPosition{MarketID: m.ID, YesShares: 10, YesAmount: 5}); err != nil {
+		t.Fatalf("SavePosition: %v", err)
+	}
+	if err := s.SavePosition(ctx, &models.UserPosition{MarketID: m.ID, YesShares: 10, NoShares: 4, YesAmount: 5, NoAmount: 2.5, Claimed: true}); err != nil {
+		t.Fatalf("SavePosition (update): %v", err)
+	}
+
+	p, err := s.GetPosition(ctx, m.ID)
+	if err != nil || p == nil {
+		t.Fatalf("GetPosition = %v, %v", p, err)
+	}
+	if !approx(p.NoShares, 4) || !approx(p.NoAmount, 2.5) || !p.Claimed {
+		t.Errorf("position not updated in place: %+v", p)
+	}
+
+	positions, _ := s.GetPositions(ctx)
+	if len(positions) != 1 {
+		t.Errorf("expected 1 position after upsert, got %d", len(positions))
+	}
+}
+
+func testBalance(t *testing.T, s Storage) {
+	start, err := s.GetBalance(ctx)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if !approx(start, 10000) {
+		t.Errorf("initial balance = %v, want 10000", start)
+	}
+
+	if err := s.UpdateBalance(ctx, -250.5); err != nil {
+		t.Fatalf("UpdateBalance: %v", err)
+	}
+	if err := s.UpdateBalance(ctx, 100); err != nil {
+		t.Fatalf("UpdateBalance: %v", err)
+	}
+	if got, _ := s.GetBalance(ctx); !approx(got, 9849.5) {
+		t.Errorf("balance = %v, want 9849.5", got)
+	}
+}
+
+func testEvents(t *testing.T, s Storage) {
+	m := mustSaveMarket(t, s, newMarket("Evented?", base))
+	balance := 42.5
+
+	var seqs []int64
+	for _, e := range []*models.Event{
+		{Type: models.EventMarketCreated, MarketID: m.ID, Market: m, Odds: &models.Odds{Yes: 0.6, No: 0.4}, Timestamp: base},
+		{Type: models.EventBalance, Balance: &balance, Timestamp: base},
+		{Type: models.EventMarketStatus, MarketID: m.ID, Timestamp: base},
+	} {
+		if err := s.AppendEvent(ctx, e); err != nil {
+			t.Fatalf("AppendEvent: %v", err)
+		}
+		seqs = append(seqs, e.Seq)
+	}
+	if seqs[0] == 0 || seqs[1] <= seqs[0] || seqs[2] <= seqs[1] {
+		t.Fatalf("expected increasing sequence numbers, got %v", seqs)
+	}
+
+	all, err := s.GetEventsSince(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("GetEventsSince: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("got %d events, want 3", len(all))
+	}
+	if all[0].Market == nil || all[0].Market.Question != "Evented?" || all[0].Odds == nil || !approx(all[0].Odds.Yes, 0.6) {
+		t.Errorf("event payload not round-tripped: %+v", all[0])
+	}
+	if all[1].Balance == nil || !approx(*all[1].Balance, 42.5) {
+		t.Errorf("balance event not round-tripped: %+v", all[1])
+	}
+
+	page, _ := s.GetEventsSince(ctx, seqs[0], 1)
+	if len(page) != 1 || page[0].Seq != seqs[1] {
+		t.Errorf("GetEventsSince(%d, 1) = %+v, want seq %d", seqs[0], page, seqs[1])
+	}
+	if rest, _ := s.GetEventsSince(ctx, seqs[2], 10); len(rest) != 0 {
+		t.Errorf("expected no events after the last sequence, got %d", len(rest))
+	}
+}
+
+func snapshot(marketID int, at time.Time, price, volume float64) *models.PriceSnapshot {
+	return &models.PriceSnapshot{
+		MarketID:  marketID,
+		Timestamp: at,
+		Open:      price,
+		High:      price,
+		Low:       price,
+		Close:     price,
+		Volume:    volume,
+		YesPool:   price * 100,
+		NoPool:    (1 - price) * 100,
+	}
+}
+
+func testPriceHistory(t *testing.T, s Storage) {
+	m := mustSaveMarket(t, s, newMarket("History?", base))
+	other := mustSaveMarket(t, s, newMarket("Other?", base))
+
+	for i, price := range []float64{0.5, 0.6, 0.55} {
+		if err := s.SavePriceSnapshot(ctx, snapshot(m.ID, base.Add(time.Duration(i)*time.Minute), price, 10)); err != nil {
+			t.Fatalf("SavePriceSnapshot: %v", err)
+		}
+	}
+	s.SavePriceSnapshot(ctx, snapshot(other.ID, base, 0.9, 10))
+
+	history, err := s.GetPriceHistory(ctx, m.ID, base, base.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("GetPriceHistory: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("got %d snapshots in [from, to), want 2", len(history))
+	}
+	if !history[0].Timestamp.Equal(base) || !approx(history[1].Close, 0.6) {
+		t.Errorf("unexpected history: %+v %+v", history[0], history[1])
+	}
+
+	deleted, err := s.DeletePriceHistoryBefore(ctx, base.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("DeletePriceHistoryBefore: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted %d rows, want 2", deleted)
+	}
+	if remaining, _ := s.GetPriceHistory(ctx, m.ID, base, base.Add(time.Hour)); len(remaining) != 2 {
+		t.Errorf("%d snapshots remain, want 2", len(remaining))
+	}
+}
+
+func testDownsample(t *testing.T, s Storage) {
+	m := mustSaveMarket(t, s, newMarket("Downsample?", base))
+
+	for i, price := range []float64{0.5, 0.7, 0.4, 0.6} {
+		s.SavePriceSnapshot(ctx, snapshot(m.ID, base.Add(time.Duration(i)*15*time.Minute), price, 5))
+	}
+	// Newer than the cutoff, so left at full resolution
+	s.SavePriceSnapshot(ctx, snapshot(m.ID, base.Add(90*time.Minute), 0.65, 5))
+
+	merged, err := s.DownsamplePriceHistory(ctx, base.Add(time.Hour), time.Hour)
+	if err != nil {
+		t.Fatalf("DownsamplePriceHistory: %v", err)
+	}
+	if merged != 1 {
+		t.Errorf("merged into %d buckets, want 1", merged)
+	}
+
+	history, _ := s.GetPriceHistory(ctx, m.ID, base, base.Add(2*time.Hour))
+	if len(history) != 2 {
+		t.Fatalf("got %d rows after downsampling, want 2", len(history))
+	}
+	b := history[0]
+	if !b.Timestamp.Equal(base) || b.Resolution != 3600 {
+		t.Errorf("bucket at %s with resolution %d, want %s and 3600", b.Timestamp, b.Resolution, base)
+	}
+	if !approx(b.Open, 0.5) || !approx(b.High, 0.7) || !approx(b.Low, 0.4) || !approx(b.Close, 0.6) || !approx(b.Volume, 20) {
+		t.Errorf("unexpected OHLCV: %+v", b)
+	}
+
+	// Already-downsampled rows are not merged again
+	if again, _ := s.DownsamplePriceHistory(ctx, base.Add(time.Hour), time.Hour); again != 0 {
+		t.Errorf("second pass merged %d buckets, want 0", again)
+	}
+}
+
+func testTrades(t *testing.T, s Storage) {
+	a := mustSaveMarket(t, s, newMarket("A?", base))
+	b := mustSaveMarket(t, s, newMarket("B?", base))
+
+	var ids []int64
+	for i, marketID := range []int{a.ID, b.ID, a.ID, a.ID} {
+		trade := &models.Trade{
+			User:      models.DefaultUser,
+			MarketID:  marketID,
+			Outcome:   models.OutcomeYes,
+			Amount:    10,
+			Shares:    12.5,
+			Price:     0.5,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		}
+		if err := s.SaveTrade(ctx, trade); err != nil {
+			t.Fatalf("SaveTrade: %v", err)
+		}
+		ids = append(ids, trade.ID)
+	}
+	if ids[0] == 0 || ids[3] <= ids[0] {
+		t.Fatalf("expected increasing trade IDs, got %v", ids)
+	}
+
+	page, err := s.GetTrades(ctx, models.TradeQuery{MarketID: a.ID, Limit: 2})
+	if err != nil {
+		t.Fatalf("GetTrades: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != ids[3] || page[1].ID != ids[2] {
+		t.Fatalf("first page = %+v, want trades %d and %d", page, ids[3], ids[2])
+	}
+
+	next, _ := s.GetTrades(ctx, models.TradeQuery{MarketID: a.ID, Before: page[1].ID, Limit: 2})
+	if len(next) != 1 || next[0].ID != ids[0] {
+		t.Errorf("second page = %+v, want trade %d", next, ids[0])
+	}
+
+	if mine, _ := s.GetTrades(ctx, models.TradeQuery{User: "someone-else", Limit: 10}); len(mine) != 0 {
+		t.Errorf("user filter returned %d trades, want 0", len(mine))
+	}
+
+	since, err := s.GetTradesSince(ctx, base.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("GetTradesSince: %v", err)
+	}
+	if len(since) != 3 || since[0].ID != ids[1] {
+		t.Errorf("GetTradesSince returned %d trades, want 3 oldest first", len(since))
+	}
+	if !since[0].CreatedAt.Equal(base.Add(time.Minute)) || !approx(since[0].Shares, 12.5) {
+		t.Errorf("trade not round-tripped: %+v", since[0])
+	}
+}
+
+func testLeaderboard(t *testing.T, s Storage) {
+	brier := func(v float64) *float64 { return &v }
+
+	entries := []*models.LeaderboardEntry{
+		{User: "alice", Volume: 100, Trades: 2, RealizedProfit: 10, WinRate: 0.5, ROI: 0.1, BrierScore: brier(0.2), RefreshedAt: base},
+		{User: "bob", Volume: 300, Trades: 5, RealizedProfit: -20, WinRate: 0.2, ROI: -0.05, RefreshedAt: base},
+		{User: "carol", Volume: 200, Trades: 3, RealizedProfit: 30, WinRate: 1, ROI: 0.3, BrierScore: brier(0.1), RefreshedAt: base},
+	}
+	if err := s.ReplaceLeaderboard(ctx, "all", entries); err != nil {
+		t.Fatalf("ReplaceLeaderboard: %v", err)
+	}
+
+	order := map[string][]string{
+		"volume":  {"bob", "carol", "alice"},
+		"profit":  {"carol", "alice", "bob"},
+		"winRate": {"carol", "alice", "bob"},
+		"roi":     {"carol", "alice", "bob"},
+		"brier":   {"carol", "alice", "bob"},
+	}
+	for metric, want := range order {
+		got, err := s.GetLeaderboard(ctx, "all", metric, 10)
+		if err != nil {
+			t.Fatalf("GetLeaderboard(%s): %v", metric, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("GetLeaderboard(%s) returned %d entries, want %d", metric, len(got), len(want))
+		}
+		for i, e := range got {
+			if e.User != want[i] || e.Rank != i+1 || e.Period != "all" {
+				t.Errorf("%s #%d = %s (rank %d, period %q), want %s", metric, i+1, e.User, e.Rank, e.Period, want[i])
+			}
+		}
+	}
+
+	top, _ := s.GetLeaderboard(ctx, "all", "profit", 1)
+	if len(top) != 1 || top[0].BrierScore == nil || !approx(*top[0].BrierScore, 0.1) {
+		t.Errorf("limit or brier score not honoured: %+v", top)
+	}
+	if _, err := s.GetLeaderboard(ctx, "all", "bogus", 10); err == nil {
+		t.Errorf("expected an error for an unknown metric")
+	}
+
+	// Replacing a period drops its previous rows but leaves other periods alone
+	s.ReplaceLeaderboard(ctx, "7d", entries[:1])
+	s.ReplaceLeaderboard(ctx, "all", entries[1:2])
+	if got, _ := s.GetLeaderboard(ctx, "all", "volume", 10); len(got) != 1 || got[0].User != "bob" {
+		t.Errorf("ReplaceLeaderboard did not replace the period's rows")
+	}
+	if got, _ := s.GetLeaderboard(ctx, "7d", "volume", 10); len(got) != 1 || got[0].User != "alice" {
+		t.Errorf("ReplaceLeaderboard touched another period")
+	}
+}
+
+func testSeedFixtures(t *testing.T, s Storage) {
+	markets, positions, err := fixtures.Seed(ctx, s, "demo")
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	saved, _ := s.GetMarkets(ctx)
+	if markets == 0 || len(saved) != markets {
+		t.Fatalf("seeded %d markets, storage has %d", markets, len(saved))
+	}
+
+	// Positions must point at the markets as saved
+	held, _ := s.GetPositions(ctx)
+	if len(held) != positions {
+		t.Fatalf("seeded %d positions, storage has %d", positions, len(held))
+	}
+	for _, p := range held {
+		if m, _ := s.GetMarket(ctx, p.MarketID); m == nil {
+			t.Errorf("position on market #%d, which does not exist", p.MarketID)
+		}
+	}
+
+	// A store with markets must not be seeded again
+	if _, _, err := fixtures.Seed(ctx, s, "demo"); !errors.Is(err, fixtures.ErrNotEmpty) {
+		t.Errorf("second Seed: got %v, want ErrNotEmpty", err)
+	}
+	again, _ := s.GetMarkets(ctx)
+	if len(again) != len(saved) {
+		t.Errorf("seeded twice: %d markets, then %d", len(saved), len(again))
+	}
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// Bet rejection codes
+const (
+	CodeInvalidOutcome      = "invalid_outcome"
+	CodeInvalidAmount       = "invalid_amount"
+	CodeBetTooSmall         = "bet_too_small"
+	CodeBetTooLarge         = "bet_too_large"
+	CodeMarketNotActive     = "market_not_active"
+	CodeInsufficientBalance = "insufficient_balance"
+	CodeExposureLimit       = "exposure_limit"
+	CodePoolImbalance       = "pool_imbalance"
+)
+
+// BetError explains why a bet was rejected
+type BetError struct {
+	Code    string
+	Message string
+}
+
+func (e *BetError) Error() string {
+	return e.Message
+}
+
+func betError(code, format string, args ...interface{}) *BetError {
+	return &BetError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// BetLimits bounds the size and risk of a single bet. A zero limit is not enforced.
+type BetLimits struct {
+	MinBet           float64 // smallest accepted amount
+	MaxBet           float64 // largest accepted amount
+	MaxExposure      float64 // most a user may stake on one market, across both outcomes
+	MaxPoolImbalance float64 // largest allowed difference between the Yes and No pools after a bet
+}
+
+// DefaultBetLimits accepts bets of 1-10,000 tokens, up to 25,000 tokens per
+// market, while the pools stay within 50,000 tokens of each other
+var DefaultBetLimits = BetLimits{
+	MinBet:           1,
+	MaxBet:           10000,
+	MaxExposure:      25000,
+	MaxPoolImbalance: 50000,
+}
+
+// CheckRequest validates a bet before anything is looked up in storage
+func (l BetLimits) CheckRequest(req models.BetRequest) error {
+	if req.Outcome != models.OutcomeYes && req.Outcome != models.OutcomeNo {
+		return betError(CodeInvalidOutcome, "outcome must be %q or %q", models.OutcomeYes, models.OutcomeNo)
+	}
+	if !(req.Amount > 0) || math.IsInf(req.Amount, 0) {
+		return betError(CodeInvalidAmount, "amount must be a positive number")
+	}
+	if l.MinBet > 0 && req.Amount < l.MinBet {
+		return betError(CodeBetTooSmall, "minimum bet is %g tokens", l.MinBet)
+	}
+	if l.MaxBet > 0 && req.Amount > l.MaxBet {
+		return betError(CodeBetTooLarge, "maximum bet is %g tokens", l.MaxBet)
+	}
+	return nil
+}
+
+// CheckBet validates a bet that passed CheckRequest, placed at now, against
+// the market it is placed on, the user's existing position (nil if none) and
+// their balance
+func (l BetLimits) CheckBet(req models.BetRequest, market *models.Market, position *models.UserPosition, balance float64, now time.Time) error {
+	if market.Status != models.StatusActive {
+		return betError(CodeMarketNotActive, "market is not active")
+	}
+	// The market may not have been locked yet after its end time passed
+	if !market.EndTime.After(now) {
+		return betError(CodeMarketNotActive, "market has ended")
+	}
+
+	if req.Amount > balance {
+		return betError(CodeInsufficientBalance, "insufficient balance")
+	}
+
+	if l.MaxExposure > 0 {
+		exposure := req.Amount
+		if position != nil {
+			exposure += position.YesAmount + position.NoAmount
+		}
+		if exposure > l.MaxExposure {
+			return betError(CodeExposureLimit, "bet would raise your stake in this market to %g tokens (limit %g)", exposure, l.MaxExposure)
+		}
+	}
+
+	if l.MaxPoolImbalance > 0 {
+		before := math.Abs(market.YesPool - market.NoPool)
+		after := math.Abs(market.YesPool - market.NoPool - req.Amount)
+		if req.Outcome == models.OutcomeYes {
+			after = math.Abs(market.YesPool + req.Amount - market.NoPool)
+		}
+		// Bets that rebalance the pools are always allowed
+		if after > l.MaxPoolImbalance && after > before {
+			return betError(CodePoolImbalance, "bet would leave the pools %g tokens apart (limit %g)", after, l.MaxPoolImbalance)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+// Package validation checks markets before they are created, whether they come
+// from the HTTP API or from the oracle, and bets before they are placed.
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linera-prediction-market/backend/internal/models"
+)
+
+// Field error codes
+const (
+	CodeRequired  = "required"
+	CodeTooShort  = "too_short"
+	CodeTooLong   = "too_long"
+	CodeInvalid   = "invalid"
+	CodeUnknown   = "unknown"
+	CodeTooSoon   = "too_soon"
+	CodeTooLate   = "too_late"
+	CodeDuplicate = "duplicate"
+)
+
+// FieldError explains why a single field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors lists every invalid field of a market
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fe := range e {
+		messages[i] = fe.Field + ": " + fe.Message
+	}
+	return "invalid market: " + strings.Join(messages, "; ")
+}
+
+// Has reports whether the field failed with the given code
+func (e Errors) Has(field, code string) bool {
+	for _, fe := range e {
+		if fe.Field == field && fe.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Errors) add(field, code, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// Rules bounds what a valid market looks like
+type Rules struct {
+	MinQuestionLength int
+	MaxQuestionLength int
+	MinHorizon        time.Duration // shortest time from now to the end time
+	MaxHorizon        time.Duration // longest time from now to the end time
+	MaxTags           int
+}
+
+// DefaultRules allows 10-200 character questions ending between one hour and two years out
+var DefaultRules = Rules{
+	MinQuestionLength: 10,
+	MaxQuestionLength: 200,
+	MinHorizon:        time.Hour,
+	MaxHorizon:        2 * 365 * 24 * time.Hour,
+	MaxTags:           10,
+}
+
+// OracleSources are the automated resolution sources accepted as "oracle:<name>"
+var OracleSources = map[string]bool{
+	"coingecko": true, // price markets resolved from CoinGecko data
+	"demo":      true, // demo markets the oracle resolves at random
+}
+
+// Store defines the storage lookups used during validation
+type Store interface {
+	GetCategoryByName(ctx context.Context, name string) (*models.Category, error)
+	GetActiveMarketByQuestion(ctx context.Context, question string) (*models.Market, error)
+}
+
+// Validator checks new markets against Rules and the current storage
+type Validator struct {
+	store Store
+	rules Rules
+	now   func() time.Time
+}
+
+// New creates a validator
+func New(s Store, rules Rules) *Validator {
+	return &Validator{
+		store: s,
+		rules: rules,
+		now:   time.Now,
+	}
+}
+
+// ValidateMarket normalizes the question and tags of a market about to be
+// created and checks every field. It returns Errors when fields are invalid,
+// or another error if storage could not be consulted.
+func (v *Validator) ValidateMarket(ctx context.Context, market *models.Market) error {
+	var errs Errors
+
+	market.Question = NormalizeQuestion(market.Question)
+	switch n := len([]rune(market.Question)); {
+	case n == 0:
+		errs.add("question", CodeRequired, "question is required")
+	case n < v.rules.MinQuestionLength:
+		errs.add("question", CodeTooShort, "question must be at least %d characters", v.rules.MinQuestionLength)
+	case n > v.rules.MaxQuestionLength:
+		errs.add("question", CodeTooLong, "question must be at most %d characters", v.rules.MaxQuestionLength)
+	default:
+		existing, err := v.store.GetActiveMarketByQuestion(ctx, market.Question)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			errs.add("question", CodeDuplicate, "an active market already asks this question (#%d)", existing.ID)
+		}
+	}
+
+	if market.Category == "" {
+		errs.add("category", CodeRequired, "category is required")
+	} else {
+		category, err := v.store.GetCategoryByName(ctx, market.Category)
+		if err != nil {
+			return err
+		}
+		if category == nil {
+			errs.add("category", CodeUnknown, "category %q does not exist", market.Category)
+		}
+	}
+
+	now := v.now()
+	switch {
+	case market.EndTime.IsZero():
+		errs.add("endTime", CodeRequired, "end time is required")
+	case market.EndTime.Before(now.Add(v.rules.MinHorizon)):
+		errs.add("endTime", CodeTooSoon, "end time must be at least %s in the future", v.rules.MinHorizon)
+	case market.EndTime.After(now.Add(v.rules.MaxHorizon)):
+		errs.add("endTime", CodeTooLate, "end time must be within %s", v.rules.MaxHorizon)
+	}
+
+	if fe := checkResolutionSource(market.ResolutionSource); fe != nil {
+		errs = append(errs, *fe)
+	}
+
+	tags, tagErrs := v.NormalizeTags(market.Tags)
+	market.Tags = tags
+	errs = append(errs, tagErrs...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// NormalizeQuestion trims a question and collapses runs of whitespace
+func NormalizeQuestion(question string) string {
+	return strings.Join(strings.Fields(question), " ")
+}
+
+func checkResolutionSource(source string) *FieldError {
+	if source == "" {
+		return &FieldError{"resolutionSource", CodeRequired, "resolution source is required"}
+	}
+
+	if name, ok := strings.CutPrefix(source, "oracle:"); ok {
+		if !OracleSources[name] {
+			return &FieldError{"resolutionSource", CodeUnknown, fmt.Sprintf("unknown oracle source %q", name)}
+		}
+		return nil
+	}
+
+	u, err := url.Parse(source)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &FieldError{"resolutionSource", CodeInvalid, "resolution source must be an http(s) URL or oracle:<name>"}
+	}
+	return nil
+}
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// NormalizeTags lower-cases, trims, de-duplicates and sorts tags, reporting
+// any that are not 1-32 letters, digits and dashes
+func (v *Validator) NormalizeTags(tags []string) ([]string, Errors) {
+	var errs Errors
+	seen := make(map[string]bool)
+	normalized := []string{}
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagPattern.MatchString(tag) {
+			errs.add("tags", CodeInvalid, "invalid tag %q (use 1-32 letters, digits or dashes)", tag)
+			continue
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	if len(normalized) > v.rules.MaxTags {
+		errs.add("tags", CodeTooLong, "at most %d tags are allowed", v.rules.MaxTags)
+	}
+	sort.Strings(normalized)
+	return normalized, errs
+}
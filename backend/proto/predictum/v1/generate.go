@@ -0,0 +1,10 @@
+// Package predictumv1 holds the gRPC services of the Predictum backend,
+// generated from predictum.proto. Regenerate it with `go generate ./proto/...`
+// (requires protoc with protoc-gen-go and protoc-gen-go-grpc on the PATH).
+//
+//	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	markets := predictumv1.NewMarketServiceClient(conn)
+//	resp, err := markets.ListMarkets(ctx, &predictumv1.ListMarketsRequest{PageSize: 10})
+package predictumv1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative predictum/v1/predictum.proto
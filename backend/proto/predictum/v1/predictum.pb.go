@@ -0,0 +1,1413 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: predictum/v1/predictum.proto
+
+package predictumv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MarketStatus int32
+
+const (
+	MarketStatus_MARKET_STATUS_UNSPECIFIED MarketStatus = 0
+	MarketStatus_MARKET_STATUS_ACTIVE      MarketStatus = 1
+	MarketStatus_MARKET_STATUS_LOCKED      MarketStatus = 2
+	MarketStatus_MARKET_STATUS_RESOLVED    MarketStatus = 3
+	MarketStatus_MARKET_STATUS_CANCELLED   MarketStatus = 4
+)
+
+// Enum value maps for MarketStatus.
+var (
+	MarketStatus_name = map[int32]string{
+		0: "MARKET_STATUS_UNSPECIFIED",
+		1: "MARKET_STATUS_ACTIVE",
+		2: "MARKET_STATUS_LOCKED",
+		3: "MARKET_STATUS_RESOLVED",
+		4: "MARKET_STATUS_CANCELLED",
+	}
+	MarketStatus_value = map[string]int32{
+		"MARKET_STATUS_UNSPECIFIED": 0,
+		"MARKET_STATUS_ACTIVE":      1,
+		"MARKET_STATUS_LOCKED":      2,
+		"MARKET_STATUS_RESOLVED":    3,
+		"MARKET_STATUS_CANCELLED":   4,
+	}
+)
+
+func (x MarketStatus) Enum() *MarketStatus {
+	p := new(MarketStatus)
+	*p = x
+	return p
+}
+
+func (x MarketStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MarketStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_predictum_v1_predictum_proto_enumTypes[0].Descriptor()
+}
+
+func (MarketStatus) Type() protoreflect.EnumType {
+	return &file_predictum_v1_predictum_proto_enumTypes[0]
+}
+
+func (x MarketStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MarketStatus.Descriptor instead.
+func (MarketStatus) EnumDescriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{0}
+}
+
+type Outcome int32
+
+const (
+	Outcome_OUTCOME_UNSPECIFIED Outcome = 0
+	Outcome_OUTCOME_YES         Outcome = 1
+	Outcome_OUTCOME_NO          Outcome = 2
+)
+
+// Enum value maps for Outcome.
+var (
+	Outcome_name = map[int32]string{
+		0: "OUTCOME_UNSPECIFIED",
+		1: "OUTCOME_YES",
+		2: "OUTCOME_NO",
+	}
+	Outcome_value = map[string]int32{
+		"OUTCOME_UNSPECIFIED": 0,
+		"OUTCOME_YES":         1,
+		"OUTCOME_NO":          2,
+	}
+)
+
+func (x Outcome) Enum() *Outcome {
+	p := new(Outcome)
+	*p = x
+	return p
+}
+
+func (x Outcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Outcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_predictum_v1_predictum_proto_enumTypes[1].Descriptor()
+}
+
+func (Outcome) Type() protoreflect.EnumType {
+	return &file_predictum_v1_predictum_proto_enumTypes[1]
+}
+
+func (x Outcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Outcome.Descriptor instead.
+func (Outcome) EnumDescriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{1}
+}
+
+type Market struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Question       string                 `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	Category       string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Status         MarketStatus           `protobuf:"varint,4,opt,name=status,proto3,enum=predictum.v1.MarketStatus" json:"status,omitempty"`
+	EndTime        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	YesPool        float64                `protobuf:"fixed64,6,opt,name=yes_pool,json=yesPool,proto3" json:"yes_pool,omitempty"`
+	NoPool         float64                `protobuf:"fixed64,7,opt,name=no_pool,json=noPool,proto3" json:"no_pool,omitempty"`
+	TotalYesShares float64                `protobuf:"fixed64,8,opt,name=total_yes_shares,json=totalYesShares,proto3" json:"total_yes_shares,omitempty"`
+	TotalNoShares  float64                `protobuf:"fixed64,9,opt,name=total_no_shares,json=totalNoShares,proto3" json:"total_no_shares,omitempty"`
+	// Unspecified until the market is resolved.
+	WinningOutcome   Outcome                `protobuf:"varint,10,opt,name=winning_outcome,json=winningOutcome,proto3,enum=predictum.v1.Outcome" json:"winning_outcome,omitempty"`
+	ResolutionSource string                 `protobuf:"bytes,11,opt,name=resolution_source,json=resolutionSource,proto3" json:"resolution_source,omitempty"`
+	Tags             []string               `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Odds             *Odds                  `protobuf:"bytes,14,opt,name=odds,proto3" json:"odds,omitempty"`
+}
+
+func (x *Market) Reset() {
+	*x = Market{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Market) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Market) ProtoMessage() {}
+
+func (x *Market) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Market.ProtoReflect.Descriptor instead.
+func (*Market) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Market) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Market) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *Market) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Market) GetStatus() MarketStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MarketStatus_MARKET_STATUS_UNSPECIFIED
+}
+
+func (x *Market) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *Market) GetYesPool() float64 {
+	if x != nil {
+		return x.YesPool
+	}
+	return 0
+}
+
+func (x *Market) GetNoPool() float64 {
+	if x != nil {
+		return x.NoPool
+	}
+	return 0
+}
+
+func (x *Market) GetTotalYesShares() float64 {
+	if x != nil {
+		return x.TotalYesShares
+	}
+	return 0
+}
+
+func (x *Market) GetTotalNoShares() float64 {
+	if x != nil {
+		return x.TotalNoShares
+	}
+	return 0
+}
+
+func (x *Market) GetWinningOutcome() Outcome {
+	if x != nil {
+		return x.WinningOutcome
+	}
+	return Outcome_OUTCOME_UNSPECIFIED
+}
+
+func (x *Market) GetResolutionSource() string {
+	if x != nil {
+		return x.ResolutionSource
+	}
+	return ""
+}
+
+func (x *Market) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Market) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Market) GetOdds() *Odds {
+	if x != nil {
+		return x.Odds
+	}
+	return nil
+}
+
+// Odds holds the implied probability of each outcome derived from the pools.
+type Odds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Yes float64 `protobuf:"fixed64,1,opt,name=yes,proto3" json:"yes,omitempty"`
+	No  float64 `protobuf:"fixed64,2,opt,name=no,proto3" json:"no,omitempty"`
+}
+
+func (x *Odds) Reset() {
+	*x = Odds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Odds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Odds) ProtoMessage() {}
+
+func (x *Odds) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Odds.ProtoReflect.Descriptor instead.
+func (*Odds) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Odds) GetYes() float64 {
+	if x != nil {
+		return x.Yes
+	}
+	return 0
+}
+
+func (x *Odds) GetNo() float64 {
+	if x != nil {
+		return x.No
+	}
+	return 0
+}
+
+type ListMarketsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unspecified lists every status.
+	Status MarketStatus `protobuf:"varint,1,opt,name=status,proto3,enum=predictum.v1.MarketStatus" json:"status,omitempty"`
+	// Also matches sub-categories.
+	Category string `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Tag      string `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	// ending-soon (default), newest, popular or alphabetical.
+	SortBy string `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// Defaults to 20; at most 100.
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of the previous page.
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListMarketsRequest) Reset() {
+	*x = ListMarketsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMarketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMarketsRequest) ProtoMessage() {}
+
+func (x *ListMarketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMarketsRequest.ProtoReflect.Descriptor instead.
+func (*ListMarketsRequest) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListMarketsRequest) GetStatus() MarketStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MarketStatus_MARKET_STATUS_UNSPECIFIED
+}
+
+func (x *ListMarketsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListMarketsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListMarketsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListMarketsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMarketsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListMarketsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Markets []*Market `protobuf:"bytes,1,rep,name=markets,proto3" json:"markets,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of markets matching the filters.
+	TotalSize int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+}
+
+func (x *ListMarketsResponse) Reset() {
+	*x = ListMarketsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMarketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMarketsResponse) ProtoMessage() {}
+
+func (x *ListMarketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMarketsResponse.ProtoReflect.Descriptor instead.
+func (*ListMarketsResponse) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListMarketsResponse) GetMarkets() []*Market {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+func (x *ListMarketsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListMarketsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type GetMarketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetMarketRequest) Reset() {
+	*x = GetMarketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMarketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarketRequest) ProtoMessage() {}
+
+func (x *GetMarketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarketRequest.ProtoReflect.Descriptor instead.
+func (*GetMarketRequest) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetMarketRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WatchMarketsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Empty watches every market.
+	MarketIds []int64 `protobuf:"varint,1,rep,packed,name=market_ids,json=marketIds,proto3" json:"market_ids,omitempty"`
+}
+
+func (x *WatchMarketsRequest) Reset() {
+	*x = WatchMarketsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchMarketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMarketsRequest) ProtoMessage() {}
+
+func (x *WatchMarketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMarketsRequest.ProtoReflect.Descriptor instead.
+func (*WatchMarketsRequest) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchMarketsRequest) GetMarketIds() []int64 {
+	if x != nil {
+		return x.MarketIds
+	}
+	return nil
+}
+
+type MarketEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sequence number shared with the SSE feed.
+	Seq int64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// market.created, bet.placed or market.status.
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	MarketId  int64                  `protobuf:"varint,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Market    *Market                `protobuf:"bytes,4,opt,name=market,proto3" json:"market,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *MarketEvent) Reset() {
+	*x = MarketEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarketEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketEvent) ProtoMessage() {}
+
+func (x *MarketEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketEvent.ProtoReflect.Descriptor instead.
+func (*MarketEvent) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MarketEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *MarketEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MarketEvent) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *MarketEvent) GetMarket() *Market {
+	if x != nil {
+		return x.Market
+	}
+	return nil
+}
+
+func (x *MarketEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type PlaceBetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MarketId int64   `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Outcome  Outcome `protobuf:"varint,2,opt,name=outcome,proto3,enum=predictum.v1.Outcome" json:"outcome,omitempty"`
+	Amount   float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *PlaceBetRequest) Reset() {
+	*x = PlaceBetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaceBetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceBetRequest) ProtoMessage() {}
+
+func (x *PlaceBetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceBetRequest.ProtoReflect.Descriptor instead.
+func (*PlaceBetRequest) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PlaceBetRequest) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *PlaceBetRequest) GetOutcome() Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return Outcome_OUTCOME_UNSPECIFIED
+}
+
+func (x *PlaceBetRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type PlaceBetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Market  *Market `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`
+	Balance float64 `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+}
+
+func (x *PlaceBetResponse) Reset() {
+	*x = PlaceBetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaceBetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceBetResponse) ProtoMessage() {}
+
+func (x *PlaceBetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceBetResponse.ProtoReflect.Descriptor instead.
+func (*PlaceBetResponse) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PlaceBetResponse) GetMarket() *Market {
+	if x != nil {
+		return x.Market
+	}
+	return nil
+}
+
+func (x *PlaceBetResponse) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type ClaimWinningsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MarketId int64 `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+}
+
+func (x *ClaimWinningsRequest) Reset() {
+	*x = ClaimWinningsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimWinningsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimWinningsRequest) ProtoMessage() {}
+
+func (x *ClaimWinningsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimWinningsRequest.ProtoReflect.Descriptor instead.
+func (*ClaimWinningsRequest) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ClaimWinningsRequest) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+type ClaimWinningsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Payout  float64 `protobuf:"fixed64,1,opt,name=payout,proto3" json:"payout,omitempty"`
+	Balance float64 `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+}
+
+func (x *ClaimWinningsResponse) Reset() {
+	*x = ClaimWinningsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimWinningsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimWinningsResponse) ProtoMessage() {}
+
+func (x *ClaimWinningsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimWinningsResponse.ProtoReflect.Descriptor instead.
+func (*ClaimWinningsResponse) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ClaimWinningsResponse) GetPayout() float64 {
+	if x != nil {
+		return x.Payout
+	}
+	return 0
+}
+
+func (x *ClaimWinningsResponse) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type ResolveMarketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MarketId int64   `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Outcome  Outcome `protobuf:"varint,2,opt,name=outcome,proto3,enum=predictum.v1.Outcome" json:"outcome,omitempty"`
+}
+
+func (x *ResolveMarketRequest) Reset() {
+	*x = ResolveMarketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveMarketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveMarketRequest) ProtoMessage() {}
+
+func (x *ResolveMarketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveMarketRequest.ProtoReflect.Descriptor instead.
+func (*ResolveMarketRequest) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ResolveMarketRequest) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+func (x *ResolveMarketRequest) GetOutcome() Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return Outcome_OUTCOME_UNSPECIFIED
+}
+
+type CancelMarketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MarketId int64 `protobuf:"varint,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+}
+
+func (x *CancelMarketRequest) Reset() {
+	*x = CancelMarketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_predictum_v1_predictum_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelMarketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMarketRequest) ProtoMessage() {}
+
+func (x *CancelMarketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_predictum_v1_predictum_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMarketRequest.ProtoReflect.Descriptor instead.
+func (*CancelMarketRequest) Descriptor() ([]byte, []int) {
+	return file_predictum_v1_predictum_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelMarketRequest) GetMarketId() int64 {
+	if x != nil {
+		return x.MarketId
+	}
+	return 0
+}
+
+var File_predictum_v1_predictum_proto protoreflect.FileDescriptor
+
+var file_predictum_v1_predictum_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2f, 0x76, 0x31, 0x2f, 0x70,
+	0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c,
+	0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa5, 0x04,
+	0x0a, 0x06, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1a, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x79,
+	0x65, 0x73, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x79,
+	0x65, 0x73, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x5f, 0x70, 0x6f, 0x6f,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6e, 0x6f, 0x50, 0x6f, 0x6f, 0x6c, 0x12,
+	0x28, 0x0a, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x79, 0x65, 0x73, 0x5f, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x59, 0x65, 0x73, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x6e, 0x6f, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x6f, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x73, 0x12, 0x3e, 0x0a, 0x0f, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x65,
+	0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d,
+	0x65, 0x52, 0x0e, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d,
+	0x65, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65,
+	0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x26, 0x0a,
+	0x04, 0x6f, 0x64, 0x64, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72,
+	0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x64, 0x64, 0x73, 0x52,
+	0x04, 0x6f, 0x64, 0x64, 0x73, 0x22, 0x28, 0x0a, 0x04, 0x4f, 0x64, 0x64, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x79, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x79, 0x65, 0x73, 0x12,
+	0x0e, 0x0a, 0x02, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x02, 0x6e, 0x6f, 0x22,
+	0xcb, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74,
+	0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74,
+	0x5f, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42,
+	0x79, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x8c, 0x01,
+	0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74,
+	0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x6d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x22, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x34, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x49, 0x64, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x65, 0x64,
+	0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52,
+	0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x22, 0x77, 0x0a, 0x0f, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x42, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x49,
+	0x64, 0x12, 0x2f, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76,
+	0x31, 0x2e, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5a, 0x0a, 0x10, 0x50, 0x6c,
+	0x61, 0x63, 0x65, 0x42, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x33, 0x0a, 0x14, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x57,
+	0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x22, 0x49, 0x0a, 0x15, 0x43,
+	0x6c, 0x61, 0x69, 0x6d, 0x57, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x64, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x07, 0x6f,
+	0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70,
+	0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x22, 0x32, 0x0a, 0x13,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x49, 0x64,
+	0x2a, 0x9a, 0x01, 0x0a, 0x0c, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1d, 0x0a, 0x19, 0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x18, 0x0a, 0x14, 0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x4d, 0x41,
+	0x52, 0x4b, 0x45, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4c, 0x4f, 0x43, 0x4b,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x56, 0x45, 0x44, 0x10, 0x03,
+	0x12, 0x1b, 0x0a, 0x17, 0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x43, 0x0a,
+	0x07, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x13, 0x4f, 0x55, 0x54, 0x43,
+	0x4f, 0x4d, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x59, 0x45, 0x53,
+	0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x4e, 0x4f,
+	0x10, 0x02, 0x32, 0xf6, 0x01, 0x0a, 0x0d, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x52, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75,
+	0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4d,
+	0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75,
+	0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75,
+	0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x4e, 0x0a, 0x0c, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x70, 0x72,
+	0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x32, 0xb5, 0x01, 0x0a, 0x0e,
+	0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49,
+	0x0a, 0x08, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x42, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x65,
+	0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x42,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x65, 0x64,
+	0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x42, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0d, 0x43, 0x6c, 0x61,
+	0x69, 0x6d, 0x57, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x65,
+	0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x57,
+	0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
+	0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c,
+	0x61, 0x69, 0x6d, 0x57, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x32, 0xa2, 0x01, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x4d,
+	0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75,
+	0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x4d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x65, 0x64,
+	0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12,
+	0x47, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12,
+	0x21, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x42, 0x4c, 0x5a, 0x4a, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x2d, 0x70, 0x72,
+	0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2f,
+	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72,
+	0x65, 0x64, 0x69, 0x63, 0x74, 0x75, 0x6d, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x72, 0x65, 0x64, 0x69,
+	0x63, 0x74, 0x75, 0x6d, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_predictum_v1_predictum_proto_rawDescOnce sync.Once
+	file_predictum_v1_predictum_proto_rawDescData = file_predictum_v1_predictum_proto_rawDesc
+)
+
+func file_predictum_v1_predictum_proto_rawDescGZIP() []byte {
+	file_predictum_v1_predictum_proto_rawDescOnce.Do(func() {
+		file_predictum_v1_predictum_proto_rawDescData = protoimpl.X.CompressGZIP(file_predictum_v1_predictum_proto_rawDescData)
+	})
+	return file_predictum_v1_predictum_proto_rawDescData
+}
+
+var file_predictum_v1_predictum_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_predictum_v1_predictum_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_predictum_v1_predictum_proto_goTypes = []any{
+	(MarketStatus)(0),             // 0: predictum.v1.MarketStatus
+	(Outcome)(0),                  // 1: predictum.v1.Outcome
+	(*Market)(nil),                // 2: predictum.v1.Market
+	(*Odds)(nil),                  // 3: predictum.v1.Odds
+	(*ListMarketsRequest)(nil),    // 4: predictum.v1.ListMarketsRequest
+	(*ListMarketsResponse)(nil),   // 5: predictum.v1.ListMarketsResponse
+	(*GetMarketRequest)(nil),      // 6: predictum.v1.GetMarketRequest
+	(*WatchMarketsRequest)(nil),   // 7: predictum.v1.WatchMarketsRequest
+	(*MarketEvent)(nil),           // 8: predictum.v1.MarketEvent
+	(*PlaceBetRequest)(nil),       // 9: predictum.v1.PlaceBetRequest
+	(*PlaceBetResponse)(nil),      // 10: predictum.v1.PlaceBetResponse
+	(*ClaimWinningsRequest)(nil),  // 11: predictum.v1.ClaimWinningsRequest
+	(*ClaimWinningsResponse)(nil), // 12: predictum.v1.ClaimWinningsResponse
+	(*ResolveMarketRequest)(nil),  // 13: predictum.v1.ResolveMarketRequest
+	(*CancelMarketRequest)(nil),   // 14: predictum.v1.CancelMarketRequest
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_predictum_v1_predictum_proto_depIdxs = []int32{
+	0,  // 0: predictum.v1.Market.status:type_name -> predictum.v1.MarketStatus
+	15, // 1: predictum.v1.Market.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 2: predictum.v1.Market.winning_outcome:type_name -> predictum.v1.Outcome
+	15, // 3: predictum.v1.Market.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 4: predictum.v1.Market.odds:type_name -> predictum.v1.Odds
+	0,  // 5: predictum.v1.ListMarketsRequest.status:type_name -> predictum.v1.MarketStatus
+	2,  // 6: predictum.v1.ListMarketsResponse.markets:type_name -> predictum.v1.Market
+	2,  // 7: predictum.v1.MarketEvent.market:type_name -> predictum.v1.Market
+	15, // 8: predictum.v1.MarketEvent.timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 9: predictum.v1.PlaceBetRequest.outcome:type_name -> predictum.v1.Outcome
+	2,  // 10: predictum.v1.PlaceBetResponse.market:type_name -> predictum.v1.Market
+	1,  // 11: predictum.v1.ResolveMarketRequest.outcome:type_name -> predictum.v1.Outcome
+	4,  // 12: predictum.v1.MarketService.ListMarkets:input_type -> predictum.v1.ListMarketsRequest
+	6,  // 13: predictum.v1.MarketService.GetMarket:input_type -> predictum.v1.GetMarketRequest
+	7,  // 14: predictum.v1.MarketService.WatchMarkets:input_type -> predictum.v1.WatchMarketsRequest
+	9,  // 15: predictum.v1.TradingService.PlaceBet:input_type -> predictum.v1.PlaceBetRequest
+	11, // 16: predictum.v1.TradingService.ClaimWinnings:input_type -> predictum.v1.ClaimWinningsRequest
+	13, // 17: predictum.v1.AdminService.ResolveMarket:input_type -> predictum.v1.ResolveMarketRequest
+	14, // 18: predictum.v1.AdminService.CancelMarket:input_type -> predictum.v1.CancelMarketRequest
+	5,  // 19: predictum.v1.MarketService.ListMarkets:output_type -> predictum.v1.ListMarketsResponse
+	2,  // 20: predictum.v1.MarketService.GetMarket:output_type -> predictum.v1.Market
+	8,  // 21: predictum.v1.MarketService.WatchMarkets:output_type -> predictum.v1.MarketEvent
+	10, // 22: predictum.v1.TradingService.PlaceBet:output_type -> predictum.v1.PlaceBetResponse
+	12, // 23: predictum.v1.TradingService.ClaimWinnings:output_type -> predictum.v1.ClaimWinningsResponse
+	2,  // 24: predictum.v1.AdminService.ResolveMarket:output_type -> predictum.v1.Market
+	2,  // 25: predictum.v1.AdminService.CancelMarket:output_type -> predictum.v1.Market
+	19, // [19:26] is the sub-list for method output_type
+	12, // [12:19] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_predictum_v1_predictum_proto_init() }
+func file_predictum_v1_predictum_proto_init() {
+	if File_predictum_v1_predictum_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_predictum_v1_predictum_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Market); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Odds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListMarketsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListMarketsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetMarketRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchMarketsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*MarketEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*PlaceBetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*PlaceBetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ClaimWinningsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ClaimWinningsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*ResolveMarketRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_predictum_v1_predictum_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelMarketRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_predictum_v1_predictum_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_predictum_v1_predictum_proto_goTypes,
+		DependencyIndexes: file_predictum_v1_predictum_proto_depIdxs,
+		EnumInfos:         file_predictum_v1_predictum_proto_enumTypes,
+		MessageInfos:      file_predictum_v1_predictum_proto_msgTypes,
+	}.Build()
+	File_predictum_v1_predictum_proto = out.File
+	file_predictum_v1_predictum_proto_rawDesc = nil
+	file_predictum_v1_predictum_proto_goTypes = nil
+	file_predictum_v1_predictum_proto_depIdxs = nil
+}
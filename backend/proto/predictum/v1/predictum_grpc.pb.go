@@ -0,0 +1,503 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: predictum/v1/predictum.proto
+
+package predictumv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MarketService_ListMarkets_FullMethodName  = "/predictum.v1.MarketService/ListMarkets"
+	MarketService_GetMarket_FullMethodName    = "/predictum.v1.MarketService/GetMarket"
+	MarketService_WatchMarkets_FullMethodName = "/predictum.v1.MarketService/WatchMarkets"
+)
+
+// MarketServiceClient is the client API for MarketService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MarketService reads and watches markets.
+type MarketServiceClient interface {
+	// ListMarkets returns a page of markets, filtered and sorted like GET /api/markets.
+	ListMarkets(ctx context.Context, in *ListMarketsRequest, opts ...grpc.CallOption) (*ListMarketsResponse, error)
+	// GetMarket returns a single market.
+	GetMarket(ctx context.Context, in *GetMarketRequest, opts ...grpc.CallOption) (*Market, error)
+	// WatchMarkets streams market creations, bets and status changes until the client cancels.
+	WatchMarkets(ctx context.Context, in *WatchMarketsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MarketEvent], error)
+}
+
+type marketServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMarketServiceClient(cc grpc.ClientConnInterface) MarketServiceClient {
+	return &marketServiceClient{cc}
+}
+
+func (c *marketServiceClient) ListMarkets(ctx context.Context, in *ListMarketsRequest, opts ...grpc.CallOption) (*ListMarketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMarketsResponse)
+	err := c.cc.Invoke(ctx, MarketService_ListMarkets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) GetMarket(ctx context.Context, in *GetMarketRequest, opts ...grpc.CallOption) (*Market, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Market)
+	err := c.cc.Invoke(ctx, MarketService_GetMarket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) WatchMarkets(ctx context.Context, in *WatchMarketsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MarketEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MarketService_ServiceDesc.Streams[0], MarketService_WatchMarkets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMarketsRequest, MarketEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MarketService_WatchMarketsClient = grpc.ServerStreamingClient[MarketEvent]
+
+// MarketServiceServer is the server API for MarketService service.
+// All implementations must embed UnimplementedMarketServiceServer
+// for forward compatibility.
+//
+// MarketService reads and watches markets.
+type MarketServiceServer interface {
+	// ListMarkets returns a page of markets, filtered and sorted like GET /api/markets.
+	ListMarkets(context.Context, *ListMarketsRequest) (*ListMarketsResponse, error)
+	// GetMarket returns a single market.
+	GetMarket(context.Context, *GetMarketRequest) (*Market, error)
+	// WatchMarkets streams market creations, bets and status changes until the client cancels.
+	WatchMarkets(*WatchMarketsRequest, grpc.ServerStreamingServer[MarketEvent]) error
+	mustEmbedUnimplementedMarketServiceServer()
+}
+
+// UnimplementedMarketServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMarketServiceServer struct{}
+
+func (UnimplementedMarketServiceServer) ListMarkets(context.Context, *ListMarketsRequest) (*ListMarketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMarkets not implemented")
+}
+func (UnimplementedMarketServiceServer) GetMarket(context.Context, *GetMarketRequest) (*Market, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarket not implemented")
+}
+func (UnimplementedMarketServiceServer) WatchMarkets(*WatchMarketsRequest, grpc.ServerStreamingServer[MarketEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMarkets not implemented")
+}
+func (UnimplementedMarketServiceServer) mustEmbedUnimplementedMarketServiceServer() {}
+func (UnimplementedMarketServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeMarketServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MarketServiceServer will
+// result in compilation errors.
+type UnsafeMarketServiceServer interface {
+	mustEmbedUnimplementedMarketServiceServer()
+}
+
+func RegisterMarketServiceServer(s grpc.ServiceRegistrar, srv MarketServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMarketServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MarketService_ServiceDesc, srv)
+}
+
+func _MarketService_ListMarkets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMarketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).ListMarkets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_ListMarkets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).ListMarkets(ctx, req.(*ListMarketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_GetMarket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMarketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).GetMarket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_GetMarket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).GetMarket(ctx, req.(*GetMarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_WatchMarkets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMarketsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MarketServiceServer).WatchMarkets(m, &grpc.GenericServerStream[WatchMarketsRequest, MarketEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MarketService_WatchMarketsServer = grpc.ServerStreamingServer[MarketEvent]
+
+// MarketService_ServiceDesc is the grpc.ServiceDesc for MarketService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MarketService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "predictum.v1.MarketService",
+	HandlerType: (*MarketServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListMarkets",
+			Handler:    _MarketService_ListMarkets_Handler,
+		},
+		{
+			MethodName: "GetMarket",
+			Handler:    _MarketService_GetMarket_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMarkets",
+			Handler:       _MarketService_WatchMarkets_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "predictum/v1/predictum.proto",
+}
+
+const (
+	TradingService_PlaceBet_FullMethodName      = "/predictum.v1.TradingService/PlaceBet"
+	TradingService_ClaimWinnings_FullMethodName = "/predictum.v1.TradingService/ClaimWinnings"
+)
+
+// TradingServiceClient is the client API for TradingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TradingService places bets and claims winnings for the account.
+type TradingServiceClient interface {
+	PlaceBet(ctx context.Context, in *PlaceBetRequest, opts ...grpc.CallOption) (*PlaceBetResponse, error)
+	// ClaimWinnings pays out a resolved market, or refunds the stake in a cancelled one.
+	ClaimWinnings(ctx context.Context, in *ClaimWinningsRequest, opts ...grpc.CallOption) (*ClaimWinningsResponse, error)
+}
+
+type tradingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTradingServiceClient(cc grpc.ClientConnInterface) TradingServiceClient {
+	return &tradingServiceClient{cc}
+}
+
+func (c *tradingServiceClient) PlaceBet(ctx context.Context, in *PlaceBetRequest, opts ...grpc.CallOption) (*PlaceBetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaceBetResponse)
+	err := c.cc.Invoke(ctx, TradingService_PlaceBet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradingServiceClient) ClaimWinnings(ctx context.Context, in *ClaimWinningsRequest, opts ...grpc.CallOption) (*ClaimWinningsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimWinningsResponse)
+	err := c.cc.Invoke(ctx, TradingService_ClaimWinnings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TradingServiceServer is the server API for TradingService service.
+// All implementations must embed UnimplementedTradingServiceServer
+// for forward compatibility.
+//
+// TradingService places bets and claims winnings for the account.
+type TradingServiceServer interface {
+	PlaceBet(context.Context, *PlaceBetRequest) (*PlaceBetResponse, error)
+	// ClaimWinnings pays out a resolved market, or refunds the stake in a cancelled one.
+	ClaimWinnings(context.Context, *ClaimWinningsRequest) (*ClaimWinningsResponse, error)
+	mustEmbedUnimplementedTradingServiceServer()
+}
+
+// UnimplementedTradingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTradingServiceServer struct{}
+
+func (UnimplementedTradingServiceServer) PlaceBet(context.Context, *PlaceBetRequest) (*PlaceBetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceBet not implemented")
+}
+func (UnimplementedTradingServiceServer) ClaimWinnings(context.Context, *ClaimWinningsRequest) (*ClaimWinningsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimWinnings not implemented")
+}
+func (UnimplementedTradingServiceServer) mustEmbedUnimplementedTradingServiceServer() {}
+func (UnimplementedTradingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeTradingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TradingServiceServer will
+// result in compilation errors.
+type UnsafeTradingServiceServer interface {
+	mustEmbedUnimplementedTradingServiceServer()
+}
+
+func RegisterTradingServiceServer(s grpc.ServiceRegistrar, srv TradingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTradingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TradingService_ServiceDesc, srv)
+}
+
+func _TradingService_PlaceBet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceBetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradingServiceServer).PlaceBet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradingService_PlaceBet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradingServiceServer).PlaceBet(ctx, req.(*PlaceBetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradingService_ClaimWinnings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimWinningsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradingServiceServer).ClaimWinnings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradingService_ClaimWinnings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradingServiceServer).ClaimWinnings(ctx, req.(*ClaimWinningsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TradingService_ServiceDesc is the grpc.ServiceDesc for TradingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TradingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "predictum.v1.TradingService",
+	HandlerType: (*TradingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlaceBet",
+			Handler:    _TradingService_PlaceBet_Handler,
+		},
+		{
+			MethodName: "ClaimWinnings",
+			Handler:    _TradingService_ClaimWinnings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "predictum/v1/predictum.proto",
+}
+
+const (
+	AdminService_ResolveMarket_FullMethodName = "/predictum.v1.AdminService/ResolveMarket"
+	AdminService_CancelMarket_FullMethodName  = "/predictum.v1.AdminService/CancelMarket"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService settles markets.
+type AdminServiceClient interface {
+	ResolveMarket(ctx context.Context, in *ResolveMarketRequest, opts ...grpc.CallOption) (*Market, error)
+	// CancelMarket voids a market so every position can claim back its stake.
+	CancelMarket(ctx context.Context, in *CancelMarketRequest, opts ...grpc.CallOption) (*Market, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ResolveMarket(ctx context.Context, in *ResolveMarketRequest, opts ...grpc.CallOption) (*Market, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Market)
+	err := c.cc.Invoke(ctx, AdminService_ResolveMarket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CancelMarket(ctx context.Context, in *CancelMarketRequest, opts ...grpc.CallOption) (*Market, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Market)
+	err := c.cc.Invoke(ctx, AdminService_CancelMarket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService settles markets.
+type AdminServiceServer interface {
+	ResolveMarket(context.Context, *ResolveMarketRequest) (*Market, error)
+	// CancelMarket voids a market so every position can claim back its stake.
+	CancelMarket(context.Context, *CancelMarketRequest) (*Market, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) ResolveMarket(context.Context, *ResolveMarketRequest) (*Market, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveMarket not implemented")
+}
+func (UnimplementedAdminServiceServer) CancelMarket(context.Context, *CancelMarketRequest) (*Market, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelMarket not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_ResolveMarket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveMarketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResolveMarket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ResolveMarket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResolveMarket(ctx, req.(*ResolveMarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CancelMarket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelMarketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CancelMarket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CancelMarket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CancelMarket(ctx, req.(*CancelMarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "predictum.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ResolveMarket",
+			Handler:    _AdminService_ResolveMarket_Handler,
+		},
+		{
+			MethodName: "CancelMarket",
+			Handler:    _AdminService_CancelMarket_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "predictum/v1/predictum.proto",
+}
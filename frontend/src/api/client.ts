@@ -97,12 +97,22 @@ export const api = {
   },
 
   // Create market
-  async createMarket(question: string, category: string, endTime: string) {
+  async createMarket(
+    question: string,
+    category: string,
+    endTime: string,
+    resolutionSource: string,
+    tags: string[] = []
+  ) {
     const res = await fetch(`${API_BASE}/markets`, {
       method: "POST",
       headers: { "Content-Type": "application/json" },
-      body: JSON.stringify({ question, category, endTime }),
+      body: JSON.stringify({ question, category, endTime, resolutionSource, tags }),
     });
+    if (!res.ok) {
+      const error = await res.json();
+      throw new Error(error.error || "Failed to create market");
+    }
     return res.json();
   },
 };